@@ -5,97 +5,625 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 
 	"github.com/botify-labs/airbyte_exporter/v2/internal/airbyte"
+	"github.com/botify-labs/airbyte_exporter/v2/version"
 )
 
 const (
 	namespace = "airbyte"
 )
 
-// collector collects and exposes Airbyte metrics.
+// collector collects and exposes Airbyte metrics; it is bound to each scrape request by requestCollector.
 type collector struct {
 	// Services
 	airbyteService *airbyte.Service
 
+	// Group metrics by Airbyte workspace
+	groupByWorkspace bool
+
+	// Serve metrics from the snapshot refreshed in the background
+	useSnapshots bool
+
+	// Exporter and Airbyte instance
+	up                    *prometheus.Desc
+	exporterBuildInfo     *prometheus.Desc
+	exporterSnapshotAge   *prometheus.Desc
+	exporterQuerySuccess  *prometheus.Desc
+	exporterQueryDuration *prometheus.Desc
+	buildInfo             *prometheus.Desc
+
 	// Airbyte connections
-	connections *prometheus.Desc
+	connections                  *prometheus.Desc
+	connectionsBrokenReference   *prometheus.Desc
+	connectionInfo               *prometheus.Desc
+	connectionSyncOverdue        *prometheus.Desc
+	connectionSyncOverdueSeconds *prometheus.Desc
+
+	connectionLastSuccessfulSyncTimestamp *prometheus.Desc
+	connectionLastFailedSyncTimestamp     *prometheus.Desc
+	connectionLastAttemptStartTimestamp   *prometheus.Desc
+	connectionLastJobDuration             *prometheus.Desc
+	connectionLastJobStatus               *prometheus.Desc
+	connectionConsecutiveFailures         *prometheus.Desc
+	connectionsNeverSynced                *prometheus.Desc
+	connectionNeverSyncedAge              *prometheus.Desc
+	connectionsSchemaChangePending        *prometheus.Desc
+	connectionSchemaChangePendingAge      *prometheus.Desc
 
 	// Airbyte connectors
-	sources      *prometheus.Desc
-	destinations *prometheus.Desc
+	sources                *prometheus.Desc
+	destinations           *prometheus.Desc
+	sourcesUnused          *prometheus.Desc
+	destinationsUnused     *prometheus.Desc
+	unusedActorLastUsedAge *prometheus.Desc
+
+	// Airbyte connector versions
+	connectorVersionActors          *prometheus.Desc
+	connectorBreakingChangeDeadline *prometheus.Desc
 
 	// Airbyte jobs
 	jobsCompleted *prometheus.Desc
 	jobsPending   *prometheus.Desc
 	jobsRunning   *prometheus.Desc
+
+	// Airbyte pending jobs age
+	jobsPendingAge       *prometheus.Desc
+	jobsPendingOldestAge *prometheus.Desc
+	jobQueueWait         *prometheus.Desc
+
+	// Airbyte running jobs age
+	jobsRunningAge              *prometheus.Desc
+	jobsRunningOldestAge        *prometheus.Desc
+	jobsRunningAttemptAge       *prometheus.Desc
+	jobsRunningOldestAttemptAge *prometheus.Desc
+
+	// Airbyte job and attempt durations
+	jobDuration     *prometheus.Desc
+	attemptDuration *prometheus.Desc
+
+	// Airbyte job attempts
+	jobAttempts    *prometheus.Desc
+	attemptsFailed *prometheus.Desc
+
+	// Airbyte job attempt failures
+	attemptFailures *prometheus.Desc
+
+	// Airbyte stream resets and refreshes
+	resetJobsCompleted    *prometheus.Desc
+	resetJobStreams       *prometheus.Desc
+	streamsPendingReset   *prometheus.Desc
+	streamsPendingRefresh *prometheus.Desc
+
+	// Airbyte sync volume
+	syncBytes            *prometheus.Desc
+	syncRecordsEmitted   *prometheus.Desc
+	syncRecordsCommitted *prometheus.Desc
+
+	// Airbyte streams
+	streamLastSyncRecordsEmitted *prometheus.Desc
+	streamLastSyncBytesEmitted   *prometheus.Desc
+	streamRunState               *prometheus.Desc
 }
 
 // NewCollector initializes and returns a Prometheus collector for Airbyte metrics.
-func NewCollector(airbyteService *airbyte.Service) *collector {
+//
+// If groupByWorkspace is true, connection, actor and job metrics carry workspace labels.
+func NewCollector(airbyteService *airbyte.Service, groupByWorkspace bool, useSnapshots bool) *collector {
 	return &collector{
-		airbyteService: airbyteService,
+		airbyteService:   airbyteService,
+		groupByWorkspace: groupByWorkspace,
+		useSnapshots:     useSnapshots,
+
+		up: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "up"),
+			"Whether metrics were successfully gathered from the Airbyte database",
+			nil,
+			nil,
+		),
+		exporterBuildInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "build_info"),
+			"Exporter build information",
+			[]string{"version", "revision", "branch", "goversion"},
+			nil,
+		),
+		exporterSnapshotAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "snapshot_age_seconds"),
+			"Time elapsed since the metrics snapshot was refreshed (seconds)",
+			nil,
+			nil,
+		),
+		exporterQuerySuccess: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "query_success"),
+			"Whether the last run of a database query succeeded",
+			[]string{"query"},
+			nil,
+		),
+		exporterQueryDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "query_duration_seconds"),
+			"Duration of the last run of a database query (seconds)",
+			[]string{"query"},
+			nil,
+		),
+		buildInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "build_info"),
+			"Airbyte instance build information",
+			[]string{"version", "deployment_id"},
+			nil,
+		),
 
 		connections: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "connections"),
 			"Connections",
-			[]string{"destination_connector", "source_connector", "status"},
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "status"),
+			nil,
+		),
+		connectionsBrokenReference: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connections_broken_reference"),
+			"Active connections referencing a deleted actor, or a deleted, deprecated or unsupported connector",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "actor_type", "reason"),
+			nil,
+		),
+		connectionInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_info"),
+			"Connection information",
+			[]string{
+				"connection_id",
+				"connection_name",
+				"workspace_id",
+				"workspace_name",
+				"destination_name",
+				"source_name",
+				"destination_connector",
+				"source_connector",
+				"status",
+			},
+			nil,
+		),
+		connectionSyncOverdue: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_sync_overdue"),
+			"Whether the next scheduled successful sync of the connection is overdue",
+			workspaceLabels(groupByWorkspace, "connection_id", "destination_connector", "source_connector", "schedule_type"),
+			nil,
+		),
+		connectionSyncOverdueSeconds: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_sync_overdue_seconds"),
+			"Time elapsed since the next scheduled successful sync of the connection was expected (seconds)",
+			workspaceLabels(groupByWorkspace, "connection_id", "destination_connector", "source_connector", "schedule_type"),
+			nil,
+		),
+		connectionLastSuccessfulSyncTimestamp: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_last_successful_sync_timestamp_seconds"),
+			"Completion time of the last successful sync job (Unix timestamp)",
+			workspaceLabels(groupByWorkspace, "connection_id"),
+			nil,
+		),
+		connectionLastFailedSyncTimestamp: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_last_failed_sync_timestamp_seconds"),
+			"Completion time of the last failed sync job (Unix timestamp)",
+			workspaceLabels(groupByWorkspace, "connection_id"),
+			nil,
+		),
+		connectionLastAttemptStartTimestamp: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_last_attempt_start_timestamp_seconds"),
+			"Start time of the last sync job attempt (Unix timestamp)",
+			workspaceLabels(groupByWorkspace, "connection_id"),
+			nil,
+		),
+		connectionLastJobDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_last_job_duration_seconds"),
+			"Duration of the last completed sync job (seconds)",
+			workspaceLabels(groupByWorkspace, "connection_id"),
+			nil,
+		),
+		connectionLastJobStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_last_job_status"),
+			"Status of the last completed sync job",
+			workspaceLabels(groupByWorkspace, "connection_id", "status"),
+			nil,
+		),
+		connectionConsecutiveFailures: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_consecutive_failures"),
+			"Failed or cancelled sync jobs since the last successful sync job",
+			workspaceLabels(groupByWorkspace, "connection_id", "destination_connector", "source_connector", "schedule_type"),
+			nil,
+		),
+		connectionsNeverSynced: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connections_never_synced"),
+			"Active connections without any successful sync job",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type"),
+			nil,
+		),
+		connectionNeverSyncedAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_never_synced_age_seconds"),
+			"Time elapsed since the creation of an active connection without any successful sync job (seconds)",
+			workspaceLabels(groupByWorkspace, "connection_id", "destination_connector", "source_connector", "schedule_type"),
+			nil,
+		),
+		connectionsSchemaChangePending: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connections_schema_change_pending"),
+			"Active connections with a pending source schema change",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "change_type", "non_breaking_change_preference"),
+			nil,
+		),
+		connectionSchemaChangePendingAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connection_schema_change_pending_age_seconds"),
+			"Time elapsed since a pending source schema change was detected for an active connection (seconds)",
+			workspaceLabels(groupByWorkspace, "connection_id", "destination_connector", "source_connector", "change_type", "non_breaking_change_preference"),
 			nil,
 		),
 		sources: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "sources"),
 			"Sources",
-			[]string{"source_connector", "tombstone"},
+			workspaceLabels(groupByWorkspace, "source_connector", "tombstone"),
 			nil,
 		),
 		destinations: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "destinations"),
 			"Destinations",
-			[]string{"destination_connector", "tombstone"},
+			workspaceLabels(groupByWorkspace, "destination_connector", "tombstone"),
+			nil,
+		),
+		sourcesUnused: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "sources_unused"),
+			"Sources not used by any active connection",
+			workspaceLabels(groupByWorkspace, "source_connector"),
+			nil,
+		),
+		destinationsUnused: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "destinations_unused"),
+			"Destinations not used by any active connection",
+			workspaceLabels(groupByWorkspace, "destination_connector"),
+			nil,
+		),
+		unusedActorLastUsedAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "unused_actor_last_used_age_seconds"),
+			"Time elapsed since an actor not used by any active connection was last used by a job, or since its creation (seconds)",
+			workspaceLabels(groupByWorkspace, "actor_id", "actor_name", "actor_type", "connector"),
+			nil,
+		),
+		connectorVersionActors: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connector_version_actors"),
+			"Actors using a given connector version",
+			workspaceLabels(groupByWorkspace, "actor_type", "connector", "docker_repository", "docker_image_tag", "release_stage", "support_level", "custom"),
+			nil,
+		),
+		connectorBreakingChangeDeadline: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "connector_breaking_change_deadline_timestamp"),
+			"Upgrade deadline of a breaking change affecting the connector version used by an actor (Unix timestamp)",
+			workspaceLabels(groupByWorkspace, "actor_id", "actor_name", "actor_type", "connector", "docker_image_tag", "breaking_change_version"),
 			nil,
 		),
 
 		jobsCompleted: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "jobs_completed_total"),
 			"Completed jobs (total)",
-			[]string{"destination_connector", "source_connector", "schedule_type", "type", "status"},
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
 			nil,
 		),
 		jobsPending: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "jobs_pending"),
 			"Pending jobs",
-			[]string{"destination_connector", "source_connector", "schedule_type", "type"},
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
 			nil,
 		),
 		jobsRunning: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "jobs_running"),
 			"Running jobs",
-			[]string{"destination_connector", "source_connector", "schedule_type", "type"},
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
+			nil,
+		),
+
+		jobsPendingAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "jobs_pending_age_seconds"),
+			"Age of pending jobs (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
+			nil,
+		),
+		jobsPendingOldestAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "jobs_pending_oldest_age_seconds"),
+			"Age of the oldest pending job (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
+			nil,
+		),
+		jobQueueWait: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "job_queue_wait_seconds"),
+			"Time completed jobs waited before their first attempt (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
+			nil,
+		),
+
+		jobsRunningAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "jobs_running_age_seconds"),
+			"Age of running jobs (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
+			nil,
+		),
+		jobsRunningOldestAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "jobs_running_oldest_age_seconds"),
+			"Age of the oldest running job (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
+			nil,
+		),
+		jobsRunningAttemptAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "jobs_running_attempt_age_seconds"),
+			"Age of the current attempt of running jobs (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
+			nil,
+		),
+		jobsRunningOldestAttemptAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "jobs_running_oldest_attempt_age_seconds"),
+			"Age of the oldest current attempt of running jobs (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
+			nil,
+		),
+
+		jobDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "job_duration_seconds"),
+			"Duration of completed jobs (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
+			nil,
+		),
+		attemptDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "attempt_duration_seconds"),
+			"Duration of completed job attempts (seconds)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
+			nil,
+		),
+
+		jobAttempts: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "job_attempts"),
+			"Attempts per completed job",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
+			nil,
+		),
+		attemptsFailed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "attempts_failed_total"),
+			"Failed job attempts (total)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type"),
 			nil,
 		),
+
+		attemptFailures: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "attempt_failures_total"),
+			"Job attempt failures, by failure origin and type (total)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "failure_origin", "failure_type"),
+			nil,
+		),
+
+		resetJobsCompleted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "reset_jobs_completed_total"),
+			"Completed jobs resetting, refreshing or clearing connection streams (total)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "type", "status"),
+			nil,
+		),
+		resetJobStreams: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "reset_job_streams_total"),
+			"Streams affected by completed jobs resetting, refreshing or clearing connection streams (total)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "type", "status"),
+			nil,
+		),
+		streamsPendingReset: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "streams_pending_reset"),
+			"Connection streams waiting to be reset or cleared",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector"),
+			nil,
+		),
+		streamsPendingRefresh: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "streams_pending_refresh"),
+			"Connection streams waiting to be refreshed",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "refresh_type"),
+			nil,
+		),
+
+		syncBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "sync_bytes_total"),
+			"Bytes emitted by completed jobs (total)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
+			nil,
+		),
+		syncRecordsEmitted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "sync_records_emitted_total"),
+			"Records emitted by completed jobs (total)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
+			nil,
+		),
+		syncRecordsCommitted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "sync_records_committed_total"),
+			"Records committed by completed jobs (total)",
+			workspaceLabels(groupByWorkspace, "destination_connector", "source_connector", "schedule_type", "type", "status"),
+			nil,
+		),
+
+		streamLastSyncRecordsEmitted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "stream_last_sync_records_emitted"),
+			"Records emitted for the stream by the last completed sync",
+			workspaceLabels(groupByWorkspace, "connection_id", "stream_namespace", "stream_name"),
+			nil,
+		),
+		streamLastSyncBytesEmitted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "stream_last_sync_bytes_emitted"),
+			"Bytes emitted for the stream by the last completed sync",
+			workspaceLabels(groupByWorkspace, "connection_id", "stream_namespace", "stream_name"),
+			nil,
+		),
+		streamRunState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "stream_run_state"),
+			"Latest run state of the stream",
+			workspaceLabels(groupByWorkspace, "connection_id", "stream_namespace", "stream_name", "run_state", "incomplete_run_cause"),
+			nil,
+		),
+	}
+}
+
+// workspaceLabels returns the given label names, followed by workspace label names if metrics are
+// grouped by workspace.
+func workspaceLabels(groupByWorkspace bool, labels ...string) []string {
+	if !groupByWorkspace {
+		return labels
 	}
+
+	return append(labels, "workspace_id", "workspace_name")
+}
+
+// labelValues returns the given label values, followed by workspace label values if metrics are
+// grouped by workspace.
+func (c *collector) labelValues(workspace airbyte.Workspace, values ...string) []string {
+	if !c.groupByWorkspace {
+		return values
+	}
+
+	return append(values, workspace.WorkspaceID, workspace.WorkspaceName)
+}
+
+// logGatherError logs an error that occurred while gathering metrics, with an entry for each failed
+// database query.
+func logGatherError(err error, msg string) {
+	var gatherErr *airbyte.GatherError
+
+	if !errors.As(err, &gatherErr) {
+		log.Error().Err(err).Msg(msg)
+		return
+	}
+
+	for _, queryErr := range gatherErr.Errors {
+		log.Error().Err(queryErr.Err).Str("query", queryErr.Query).Msg(msg)
+	}
+}
+
+// metrics returns the latest metrics snapshot if snapshots are enabled, or metrics gathered on the spot
+// otherwise.
+func (c *collector) metrics(ctx context.Context, ch chan<- prometheus.Metric) *airbyte.Metrics {
+	if !c.useSnapshots {
+		metrics, err := c.airbyteService.GatherMetrics(ctx)
+		if err != nil {
+			logGatherError(err, "failed to gather metrics")
+		}
+
+		return metrics
+	}
+
+	snapshot := c.airbyteService.LatestSnapshot()
+	if snapshot == nil {
+		log.Warn().Msg("no metrics snapshot available yet")
+		return &airbyte.Metrics{}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.exporterSnapshotAge,
+		prometheus.GaugeValue,
+		time.Since(snapshot.Timestamp).Seconds(),
+	)
+
+	return snapshot.Metrics
 }
 
 // Describe publishes the description of each Airbyte metric to a metrics
 // channel.
 func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.exporterBuildInfo
+	ch <- c.exporterSnapshotAge
+	ch <- c.exporterQuerySuccess
+	ch <- c.exporterQueryDuration
+	ch <- c.buildInfo
 	ch <- c.connections
+	ch <- c.connectionsBrokenReference
+	ch <- c.connectionInfo
+	ch <- c.connectionSyncOverdue
+	ch <- c.connectionSyncOverdueSeconds
+	ch <- c.connectionLastSuccessfulSyncTimestamp
+	ch <- c.connectionLastFailedSyncTimestamp
+	ch <- c.connectionLastAttemptStartTimestamp
+	ch <- c.connectionLastJobDuration
+	ch <- c.connectionLastJobStatus
+	ch <- c.connectionConsecutiveFailures
+	ch <- c.connectionsNeverSynced
+	ch <- c.connectionNeverSyncedAge
+	ch <- c.connectionsSchemaChangePending
+	ch <- c.connectionSchemaChangePendingAge
 	ch <- c.sources
 	ch <- c.destinations
+	ch <- c.sourcesUnused
+	ch <- c.destinationsUnused
+	ch <- c.unusedActorLastUsedAge
+	ch <- c.connectorVersionActors
+	ch <- c.connectorBreakingChangeDeadline
 	ch <- c.jobsCompleted
 	ch <- c.jobsPending
 	ch <- c.jobsRunning
+	ch <- c.jobsPendingAge
+	ch <- c.jobsPendingOldestAge
+	ch <- c.jobQueueWait
+	ch <- c.jobsRunningAge
+	ch <- c.jobsRunningOldestAge
+	ch <- c.jobsRunningAttemptAge
+	ch <- c.jobsRunningOldestAttemptAge
+	ch <- c.jobDuration
+	ch <- c.attemptDuration
+	ch <- c.jobAttempts
+	ch <- c.attemptsFailed
+	ch <- c.attemptFailures
+	ch <- c.resetJobsCompleted
+	ch <- c.resetJobStreams
+	ch <- c.streamsPendingReset
+	ch <- c.streamsPendingRefresh
+	ch <- c.syncBytes
+	ch <- c.syncRecordsEmitted
+	ch <- c.syncRecordsCommitted
+	ch <- c.streamLastSyncRecordsEmitted
+	ch <- c.streamLastSyncBytesEmitted
+	ch <- c.streamRunState
 }
 
-// Collect gathers metrics from Airbyte.
-func (c *collector) Collect(ch chan<- prometheus.Metric) {
-	metrics, err := c.airbyteService.GatherMetrics()
-	if err != nil {
-		log.Error().Err(err).Msg("failed to gather metrics")
+// collect gathers metrics from Airbyte, canceling database queries when ctx is done.
+func (c *collector) collect(ctx context.Context, ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		c.exporterBuildInfo,
+		prometheus.GaugeValue,
+		1,
+		version.Version,
+		version.Revision,
+		version.Branch,
+		version.GoVersion,
+	)
+
+	metrics := c.metrics(ctx, ch)
+
+	// Failed queries are reported by the query success gauge: the exporter is only considered down when
+	// no metrics could be gathered at all.
+	up := 1.0
+	if !metrics.Gathered() {
+		up = 0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, up)
+
+	for _, query := range metrics.Queries {
+		success := 1.0
+		if query.Err != nil {
+			success = 0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.exporterQuerySuccess,
+			prometheus.GaugeValue,
+			success,
+			query.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.exporterQueryDuration,
+			prometheus.GaugeValue,
+			query.Duration.Seconds(),
+			query.Name,
+		)
 	}
 
 	// Counters
@@ -104,23 +632,332 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			c.jobsCompleted,
 			prometheus.CounterValue,
 			float64(jobsCompleted.Count),
-			jobsCompleted.DestinationConnector,
-			jobsCompleted.SourceConnector,
-			jobsCompleted.ScheduleType,
-			jobsCompleted.Type,
-			jobsCompleted.Status,
+			c.labelValues(
+				jobsCompleted.Workspace,
+				jobsCompleted.DestinationConnector,
+				jobsCompleted.SourceConnector,
+				jobsCompleted.ScheduleType,
+				jobsCompleted.Type,
+				jobsCompleted.Status,
+			)...,
+		)
+	}
+
+	for _, attemptsFailed := range metrics.AttemptsFailed {
+		ch <- prometheus.MustNewConstMetric(
+			c.attemptsFailed,
+			prometheus.CounterValue,
+			float64(attemptsFailed.Count),
+			c.labelValues(
+				attemptsFailed.Workspace,
+				attemptsFailed.DestinationConnector,
+				attemptsFailed.SourceConnector,
+				attemptsFailed.ScheduleType,
+				attemptsFailed.Type,
+			)...,
+		)
+	}
+
+	for _, attemptFailures := range metrics.AttemptFailures {
+		ch <- prometheus.MustNewConstMetric(
+			c.attemptFailures,
+			prometheus.CounterValue,
+			float64(attemptFailures.Count),
+			c.labelValues(
+				attemptFailures.Workspace,
+				attemptFailures.DestinationConnector,
+				attemptFailures.SourceConnector,
+				attemptFailures.ScheduleType,
+				attemptFailures.Type,
+				attemptFailures.FailureOrigin,
+				attemptFailures.FailureType,
+			)...,
+		)
+	}
+
+	for _, resetJobsCompleted := range metrics.ResetJobsCompleted {
+		labelValues := c.labelValues(
+			resetJobsCompleted.Workspace,
+			resetJobsCompleted.DestinationConnector,
+			resetJobsCompleted.SourceConnector,
+			resetJobsCompleted.Type,
+			resetJobsCompleted.Status,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.resetJobsCompleted,
+			prometheus.CounterValue,
+			float64(resetJobsCompleted.Count),
+			labelValues...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.resetJobStreams,
+			prometheus.CounterValue,
+			float64(resetJobsCompleted.Streams),
+			labelValues...,
+		)
+	}
+
+	for _, syncVolume := range metrics.SyncVolumes {
+		labelValues := c.labelValues(
+			syncVolume.Workspace,
+			syncVolume.DestinationConnector,
+			syncVolume.SourceConnector,
+			syncVolume.ScheduleType,
+			syncVolume.Type,
+			syncVolume.Status,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.syncBytes,
+			prometheus.CounterValue,
+			float64(syncVolume.BytesEmitted),
+			labelValues...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.syncRecordsEmitted,
+			prometheus.CounterValue,
+			float64(syncVolume.RecordsEmitted),
+			labelValues...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.syncRecordsCommitted,
+			prometheus.CounterValue,
+			float64(syncVolume.RecordsCommitted),
+			labelValues...,
 		)
 	}
 
 	// Gauges
+	for _, buildInfo := range metrics.BuildInfo {
+		ch <- prometheus.MustNewConstMetric(
+			c.buildInfo,
+			prometheus.GaugeValue,
+			1,
+			buildInfo.Version,
+			buildInfo.DeploymentID,
+		)
+	}
+
 	for _, connections := range metrics.Connections {
 		ch <- prometheus.MustNewConstMetric(
 			c.connections,
 			prometheus.GaugeValue,
 			float64(connections.Count),
-			connections.DestinationConnector,
-			connections.SourceConnector,
-			connections.Status,
+			c.labelValues(
+				connections.Workspace,
+				connections.DestinationConnector,
+				connections.SourceConnector,
+				connections.Status,
+			)...,
+		)
+	}
+
+	for _, connectionsBrokenReference := range metrics.ConnectionsBrokenReferences {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionsBrokenReference,
+			prometheus.GaugeValue,
+			float64(connectionsBrokenReference.Count),
+			c.labelValues(
+				connectionsBrokenReference.Workspace,
+				connectionsBrokenReference.DestinationConnector,
+				connectionsBrokenReference.SourceConnector,
+				connectionsBrokenReference.ActorType,
+				connectionsBrokenReference.Reason,
+			)...,
+		)
+	}
+
+	for _, connectionInfo := range metrics.ConnectionsInfo {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionInfo,
+			prometheus.GaugeValue,
+			1,
+			connectionInfo.ID,
+			connectionInfo.Name,
+			connectionInfo.WorkspaceID,
+			connectionInfo.WorkspaceName,
+			connectionInfo.DestinationName,
+			connectionInfo.SourceName,
+			connectionInfo.DestinationConnector,
+			connectionInfo.SourceConnector,
+			connectionInfo.Status,
+		)
+	}
+
+	now := time.Now()
+
+	for _, connectionSyncSchedule := range metrics.ConnectionsSyncSchedules {
+		overdue, err := connectionSyncSchedule.Overdue(now)
+		if errors.Is(err, airbyte.ErrUnsupportedCronExpression) {
+			log.
+				Debug().
+				Err(err).
+				Str("connection_id", connectionSyncSchedule.ID).
+				Msg("skipping the next scheduled sync for connection")
+			continue
+		}
+		if err != nil {
+			log.
+				Error().
+				Err(err).
+				Str("connection_id", connectionSyncSchedule.ID).
+				Msg("failed to compute the next scheduled sync for connection")
+			continue
+		}
+
+		labelValues := c.labelValues(
+			connectionSyncSchedule.Workspace,
+			connectionSyncSchedule.ID,
+			connectionSyncSchedule.DestinationConnector,
+			connectionSyncSchedule.SourceConnector,
+			connectionSyncSchedule.ScheduleType,
+		)
+
+		var isOverdue float64
+		if overdue > 0 {
+			isOverdue = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionSyncOverdue,
+			prometheus.GaugeValue,
+			isOverdue,
+			labelValues...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionSyncOverdueSeconds,
+			prometheus.GaugeValue,
+			overdue.Seconds(),
+			labelValues...,
+		)
+	}
+
+	for _, connectionLastSync := range metrics.ConnectionsLastSyncs {
+		labelValues := c.labelValues(connectionLastSync.Workspace, connectionLastSync.ID)
+
+		if connectionLastSync.LastSuccessfulSync != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.connectionLastSuccessfulSyncTimestamp,
+				prometheus.GaugeValue,
+				float64(connectionLastSync.LastSuccessfulSync.Unix()),
+				labelValues...,
+			)
+		}
+
+		if connectionLastSync.LastFailedSync != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.connectionLastFailedSyncTimestamp,
+				prometheus.GaugeValue,
+				float64(connectionLastSync.LastFailedSync.Unix()),
+				labelValues...,
+			)
+		}
+
+		if connectionLastSync.LastAttemptStart != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.connectionLastAttemptStartTimestamp,
+				prometheus.GaugeValue,
+				float64(connectionLastSync.LastAttemptStart.Unix()),
+				labelValues...,
+			)
+		}
+
+		if connectionLastSync.LastJobDurationSeconds != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.connectionLastJobDuration,
+				prometheus.GaugeValue,
+				*connectionLastSync.LastJobDurationSeconds,
+				labelValues...,
+			)
+		}
+
+		if connectionLastSync.LastJobStatus != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.connectionLastJobStatus,
+				prometheus.GaugeValue,
+				1,
+				c.labelValues(
+					connectionLastSync.Workspace,
+					connectionLastSync.ID,
+					*connectionLastSync.LastJobStatus,
+				)...,
+			)
+		}
+	}
+
+	for _, connectionConsecutiveFailures := range metrics.ConnectionsConsecutiveFailures {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionConsecutiveFailures,
+			prometheus.GaugeValue,
+			float64(connectionConsecutiveFailures.Count),
+			c.labelValues(
+				connectionConsecutiveFailures.Workspace,
+				connectionConsecutiveFailures.ID,
+				connectionConsecutiveFailures.DestinationConnector,
+				connectionConsecutiveFailures.SourceConnector,
+				connectionConsecutiveFailures.ScheduleType,
+			)...,
+		)
+	}
+
+	for _, connectionsNeverSynced := range metrics.ConnectionsNeverSynced {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionsNeverSynced,
+			prometheus.GaugeValue,
+			float64(connectionsNeverSynced.Count),
+			c.labelValues(
+				connectionsNeverSynced.Workspace,
+				connectionsNeverSynced.DestinationConnector,
+				connectionsNeverSynced.SourceConnector,
+				connectionsNeverSynced.ScheduleType,
+			)...,
+		)
+	}
+
+	for _, connectionNeverSyncedAge := range metrics.ConnectionsNeverSyncedAges {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionNeverSyncedAge,
+			prometheus.GaugeValue,
+			connectionNeverSyncedAge.Seconds,
+			c.labelValues(
+				connectionNeverSyncedAge.Workspace,
+				connectionNeverSyncedAge.ID,
+				connectionNeverSyncedAge.DestinationConnector,
+				connectionNeverSyncedAge.SourceConnector,
+				connectionNeverSyncedAge.ScheduleType,
+			)...,
+		)
+	}
+
+	for _, connectionsSchemaChangePending := range metrics.ConnectionsSchemaChangesPending {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionsSchemaChangePending,
+			prometheus.GaugeValue,
+			float64(connectionsSchemaChangePending.Count),
+			c.labelValues(
+				connectionsSchemaChangePending.Workspace,
+				connectionsSchemaChangePending.DestinationConnector,
+				connectionsSchemaChangePending.SourceConnector,
+				connectionsSchemaChangePending.ChangeType,
+				connectionsSchemaChangePending.NonBreakingChangePreference,
+			)...,
+		)
+	}
+
+	for _, connectionSchemaChangeAge := range metrics.ConnectionsSchemaChangeAges {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionSchemaChangePendingAge,
+			prometheus.GaugeValue,
+			connectionSchemaChangeAge.Seconds,
+			c.labelValues(
+				connectionSchemaChangeAge.Workspace,
+				connectionSchemaChangeAge.ID,
+				connectionSchemaChangeAge.DestinationConnector,
+				connectionSchemaChangeAge.SourceConnector,
+				connectionSchemaChangeAge.ChangeType,
+				connectionSchemaChangeAge.NonBreakingChangePreference,
+			)...,
 		)
 	}
 
@@ -129,8 +966,11 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			c.sources,
 			prometheus.GaugeValue,
 			float64(sources.Count),
-			sources.ActorConnector,
-			strconv.FormatBool(sources.Tombstone),
+			c.labelValues(
+				sources.Workspace,
+				sources.ActorConnector,
+				strconv.FormatBool(sources.Tombstone),
+			)...,
 		)
 	}
 
@@ -139,8 +979,99 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			c.destinations,
 			prometheus.GaugeValue,
 			float64(destinations.Count),
-			destinations.ActorConnector,
-			strconv.FormatBool(destinations.Tombstone),
+			c.labelValues(
+				destinations.Workspace,
+				destinations.ActorConnector,
+				strconv.FormatBool(destinations.Tombstone),
+			)...,
+		)
+	}
+
+	for _, sourcesUnused := range metrics.SourcesUnused {
+		ch <- prometheus.MustNewConstMetric(
+			c.sourcesUnused,
+			prometheus.GaugeValue,
+			float64(sourcesUnused.Count),
+			c.labelValues(
+				sourcesUnused.Workspace,
+				sourcesUnused.ActorConnector,
+			)...,
+		)
+	}
+
+	for _, destinationsUnused := range metrics.DestinationsUnused {
+		ch <- prometheus.MustNewConstMetric(
+			c.destinationsUnused,
+			prometheus.GaugeValue,
+			float64(destinationsUnused.Count),
+			c.labelValues(
+				destinationsUnused.Workspace,
+				destinationsUnused.ActorConnector,
+			)...,
+		)
+	}
+
+	for _, unusedActor := range metrics.UnusedActors {
+		ch <- prometheus.MustNewConstMetric(
+			c.unusedActorLastUsedAge,
+			prometheus.GaugeValue,
+			unusedActor.Seconds,
+			c.labelValues(
+				unusedActor.Workspace,
+				unusedActor.ActorID,
+				unusedActor.ActorName,
+				unusedActor.ActorType,
+				unusedActor.ActorConnector,
+			)...,
+		)
+	}
+
+	for _, connectorVersion := range metrics.ConnectorVersions {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectorVersionActors,
+			prometheus.GaugeValue,
+			float64(connectorVersion.Count),
+			c.labelValues(
+				connectorVersion.Workspace,
+				connectorVersion.ActorType,
+				connectorVersion.ActorConnector,
+				connectorVersion.DockerRepository,
+				connectorVersion.DockerImageTag,
+				connectorVersion.ReleaseStage,
+				connectorVersion.SupportLevel,
+				strconv.FormatBool(connectorVersion.Custom),
+			)...,
+		)
+	}
+
+	for _, actorBreakingChange := range metrics.ActorsBreakingChanges {
+		affected, err := actorBreakingChange.Affected()
+		if err != nil {
+			log.
+				Debug().
+				Err(err).
+				Str("actor_id", actorBreakingChange.ActorID).
+				Msg("failed to compare the actor's connector version with the breaking change version")
+			continue
+		}
+
+		if !affected {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.connectorBreakingChangeDeadline,
+			prometheus.GaugeValue,
+			float64(actorBreakingChange.UpgradeDeadline.Unix()),
+			c.labelValues(
+				actorBreakingChange.Workspace,
+				actorBreakingChange.ActorID,
+				actorBreakingChange.ActorName,
+				actorBreakingChange.ActorType,
+				actorBreakingChange.ActorConnector,
+				actorBreakingChange.DockerImageTag,
+				actorBreakingChange.Version,
+			)...,
 		)
 	}
 
@@ -149,10 +1080,13 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			c.jobsPending,
 			prometheus.GaugeValue,
 			float64(jobsPending.Count),
-			jobsPending.DestinationConnector,
-			jobsPending.SourceConnector,
-			jobsPending.ScheduleType,
-			jobsPending.Type,
+			c.labelValues(
+				jobsPending.Workspace,
+				jobsPending.DestinationConnector,
+				jobsPending.SourceConnector,
+				jobsPending.ScheduleType,
+				jobsPending.Type,
+			)...,
 		)
 	}
 
@@ -161,10 +1095,123 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			c.jobsRunning,
 			prometheus.GaugeValue,
 			float64(jobsRunning.Count),
-			jobsRunning.DestinationConnector,
-			jobsRunning.SourceConnector,
-			jobsRunning.ScheduleType,
-			jobsRunning.Type,
+			c.labelValues(
+				jobsRunning.Workspace,
+				jobsRunning.DestinationConnector,
+				jobsRunning.SourceConnector,
+				jobsRunning.ScheduleType,
+				jobsRunning.Type,
+			)...,
+		)
+	}
+
+	for _, streamSyncStats := range metrics.StreamsLastSyncStats {
+		labelValues := c.labelValues(
+			streamSyncStats.Workspace,
+			streamSyncStats.ConnectionID,
+			streamSyncStats.StreamNamespace,
+			streamSyncStats.StreamName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.streamLastSyncRecordsEmitted,
+			prometheus.GaugeValue,
+			float64(streamSyncStats.RecordsEmitted),
+			labelValues...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.streamLastSyncBytesEmitted,
+			prometheus.GaugeValue,
+			float64(streamSyncStats.BytesEmitted),
+			labelValues...,
+		)
+	}
+
+	for _, streamStatus := range metrics.StreamsStatus {
+		ch <- prometheus.MustNewConstMetric(
+			c.streamRunState,
+			prometheus.GaugeValue,
+			1,
+			c.labelValues(
+				streamStatus.Workspace,
+				streamStatus.ConnectionID,
+				streamStatus.StreamNamespace,
+				streamStatus.StreamName,
+				streamStatus.RunState,
+				streamStatus.IncompleteRunCause,
+			)...,
+		)
+	}
+
+	for _, jobsPendingAge := range metrics.JobsPendingAges {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobsPendingOldestAge,
+			prometheus.GaugeValue,
+			jobsPendingAge.Oldest,
+			c.labelValues(
+				jobsPendingAge.Workspace,
+				jobsPendingAge.DestinationConnector,
+				jobsPendingAge.SourceConnector,
+				jobsPendingAge.ScheduleType,
+				jobsPendingAge.Type,
+			)...,
+		)
+	}
+
+	for _, jobsRunningAge := range metrics.JobsRunningAges {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobsRunningOldestAge,
+			prometheus.GaugeValue,
+			jobsRunningAge.Oldest,
+			c.labelValues(
+				jobsRunningAge.Workspace,
+				jobsRunningAge.DestinationConnector,
+				jobsRunningAge.SourceConnector,
+				jobsRunningAge.ScheduleType,
+				jobsRunningAge.Type,
+			)...,
+		)
+	}
+
+	for _, attemptsRunningAge := range metrics.AttemptsRunningAges {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobsRunningOldestAttemptAge,
+			prometheus.GaugeValue,
+			attemptsRunningAge.Oldest,
+			c.labelValues(
+				attemptsRunningAge.Workspace,
+				attemptsRunningAge.DestinationConnector,
+				attemptsRunningAge.SourceConnector,
+				attemptsRunningAge.ScheduleType,
+				attemptsRunningAge.Type,
+			)...,
+		)
+	}
+
+	for _, streamsPendingReset := range metrics.StreamsPendingReset {
+		ch <- prometheus.MustNewConstMetric(
+			c.streamsPendingReset,
+			prometheus.GaugeValue,
+			float64(streamsPendingReset.Count),
+			c.labelValues(
+				streamsPendingReset.Workspace,
+				streamsPendingReset.DestinationConnector,
+				streamsPendingReset.SourceConnector,
+			)...,
+		)
+	}
+
+	for _, streamsPendingRefresh := range metrics.StreamsPendingRefresh {
+		ch <- prometheus.MustNewConstMetric(
+			c.streamsPendingRefresh,
+			prometheus.GaugeValue,
+			float64(streamsPendingRefresh.Count),
+			c.labelValues(
+				streamsPendingRefresh.Workspace,
+				streamsPendingRefresh.DestinationConnector,
+				streamsPendingRefresh.SourceConnector,
+				streamsPendingRefresh.RefreshType,
+			)...,
 		)
 	}
 
@@ -176,7 +1223,7 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			Help:      "Age of the last successful sync job (hours)",
 			Buckets:   []float64{6, 12, 18, 24, 48, 72, 168},
 		},
-		[]string{"destination_connector", "source_connector", "schedule_type"},
+		workspaceLabels(c.groupByWorkspace, "destination_connector", "source_connector", "schedule_type"),
 	)
 
 	for _, connectionLastSuccessfulSyncAge := range metrics.ConnectionsLastSuccessfulSyncAges {
@@ -192,12 +1239,143 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 
 		connectionsLastSuccessfulSyncHistogramVec.
 			WithLabelValues(
-				connectionLastSuccessfulSyncAge.DestinationConnector,
-				connectionLastSuccessfulSyncAge.SourceConnector,
-				connectionLastSuccessfulSyncAge.ScheduleType,
+				c.labelValues(
+					connectionLastSuccessfulSyncAge.Workspace,
+					connectionLastSuccessfulSyncAge.DestinationConnector,
+					connectionLastSuccessfulSyncAge.SourceConnector,
+					connectionLastSuccessfulSyncAge.ScheduleType,
+				)...,
 			).
 			Observe(age.Hours())
 	}
 
 	connectionsLastSuccessfulSyncHistogramVec.Collect(ch)
+
+	for _, jobDuration := range metrics.JobDurations {
+		ch <- prometheus.MustNewConstHistogram(
+			c.jobDuration,
+			jobDuration.Count,
+			jobDuration.Sum,
+			jobDuration.Buckets(airbyte.JobDurationBuckets),
+			c.labelValues(
+				jobDuration.Workspace,
+				jobDuration.DestinationConnector,
+				jobDuration.SourceConnector,
+				jobDuration.ScheduleType,
+				jobDuration.Type,
+				jobDuration.Status,
+			)...,
+		)
+	}
+
+	for _, attemptDuration := range metrics.AttemptDurations {
+		ch <- prometheus.MustNewConstHistogram(
+			c.attemptDuration,
+			attemptDuration.Count,
+			attemptDuration.Sum,
+			attemptDuration.Buckets(airbyte.JobDurationBuckets),
+			c.labelValues(
+				attemptDuration.Workspace,
+				attemptDuration.DestinationConnector,
+				attemptDuration.SourceConnector,
+				attemptDuration.ScheduleType,
+				attemptDuration.Type,
+				attemptDuration.Status,
+			)...,
+		)
+	}
+
+	for _, jobAttempts := range metrics.JobAttempts {
+		ch <- prometheus.MustNewConstHistogram(
+			c.jobAttempts,
+			jobAttempts.Count,
+			jobAttempts.Sum,
+			jobAttempts.Buckets(airbyte.JobAttemptsBuckets),
+			c.labelValues(
+				jobAttempts.Workspace,
+				jobAttempts.DestinationConnector,
+				jobAttempts.SourceConnector,
+				jobAttempts.ScheduleType,
+				jobAttempts.Type,
+				jobAttempts.Status,
+			)...,
+		)
+	}
+
+	for _, jobsPendingAge := range metrics.JobsPendingAges {
+		ch <- prometheus.MustNewConstHistogram(
+			c.jobsPendingAge,
+			jobsPendingAge.Count,
+			jobsPendingAge.Sum,
+			jobsPendingAge.Buckets(airbyte.JobWaitBuckets),
+			c.labelValues(
+				jobsPendingAge.Workspace,
+				jobsPendingAge.DestinationConnector,
+				jobsPendingAge.SourceConnector,
+				jobsPendingAge.ScheduleType,
+				jobsPendingAge.Type,
+			)...,
+		)
+	}
+
+	for _, jobQueueWait := range metrics.JobsQueueWaits {
+		ch <- prometheus.MustNewConstHistogram(
+			c.jobQueueWait,
+			jobQueueWait.Count,
+			jobQueueWait.Sum,
+			jobQueueWait.Buckets(airbyte.JobWaitBuckets),
+			c.labelValues(
+				jobQueueWait.Workspace,
+				jobQueueWait.DestinationConnector,
+				jobQueueWait.SourceConnector,
+				jobQueueWait.ScheduleType,
+				jobQueueWait.Type,
+				jobQueueWait.Status,
+			)...,
+		)
+	}
+
+	for _, jobsRunningAge := range metrics.JobsRunningAges {
+		ch <- prometheus.MustNewConstHistogram(
+			c.jobsRunningAge,
+			jobsRunningAge.Count,
+			jobsRunningAge.Sum,
+			jobsRunningAge.Buckets(airbyte.JobAgeBuckets),
+			c.labelValues(
+				jobsRunningAge.Workspace,
+				jobsRunningAge.DestinationConnector,
+				jobsRunningAge.SourceConnector,
+				jobsRunningAge.ScheduleType,
+				jobsRunningAge.Type,
+			)...,
+		)
+	}
+
+	for _, attemptsRunningAge := range metrics.AttemptsRunningAges {
+		ch <- prometheus.MustNewConstHistogram(
+			c.jobsRunningAttemptAge,
+			attemptsRunningAge.Count,
+			attemptsRunningAge.Sum,
+			attemptsRunningAge.Buckets(airbyte.JobAgeBuckets),
+			c.labelValues(
+				attemptsRunningAge.Workspace,
+				attemptsRunningAge.DestinationConnector,
+				attemptsRunningAge.SourceConnector,
+				attemptsRunningAge.ScheduleType,
+				attemptsRunningAge.Type,
+			)...,
+		)
+	}
+}
+
+// requestCollector binds a collector to the context of a scrape request.
+type requestCollector struct {
+	*collector
+
+	ctx context.Context
+}
+
+// Collect gathers metrics from Airbyte, canceling database queries when the scrape request is done.
+func (rc requestCollector) Collect(ch chan<- prometheus.Metric) {
+	rc.collector.collect(rc.ctx, ch)
 }
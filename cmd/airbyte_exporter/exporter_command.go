@@ -10,7 +10,9 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -32,6 +34,8 @@ const (
 	defaultDatabasePassword string = "airbyte_exporter"
 
 	databaseDriver string = "pgx"
+
+	defaultMaxConcurrentQueries int = 4
 )
 
 var (
@@ -54,6 +58,14 @@ var (
 	databaseName     string
 	databaseUser     string
 	databasePassword string
+
+	groupByWorkspace   bool
+	streamMetrics      bool
+	unusedActorMetrics bool
+
+	refreshInterval      time.Duration
+	maxConcurrentQueries int
+	queryTimeout         time.Duration
 )
 
 // NewExporterCommand initializes the exporter's CLI entrypoint and command flags.
@@ -104,7 +116,24 @@ func NewExporterCommand() *cobra.Command {
 			)
 
 			// Database connection pool
-			pgxPool, err := pgxpool.New(context.Background(), databaseURI)
+			pgxConfig, err := pgxpool.ParseConfig(databaseURI)
+			if err != nil {
+				log.Error().
+					Err(err).
+					Str("database_driver", databaseDriver).
+					Str("database_addr", databaseAddr).
+					Str("database_name", databaseName).
+					Msg("database: failed to parse connection string")
+				return err
+			}
+
+			if queryTimeout > 0 {
+				// Let the database server cancel statements that exceed the query timeout, even if the
+				// exporter fails to send a cancel request.
+				pgxConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(queryTimeout.Milliseconds(), 10)
+			}
+
+			pgxPool, err := pgxpool.NewWithConfig(context.Background(), pgxConfig)
 			if err != nil {
 				log.Error().
 					Err(err).
@@ -132,10 +161,24 @@ func NewExporterCommand() *cobra.Command {
 				Msg("database: successfully created connection pool")
 
 			// Airbyte Exporter services
-			airbyteRepository := airbyte.NewRepository(pgxPool)
-			airbyteService := airbyte.NewService(airbyteRepository)
+			airbyteRepository := airbyte.NewRepository(pgxPool, groupByWorkspace)
+			airbyteService := airbyte.NewService(
+				airbyteRepository,
+				airbyte.ServiceOptions{
+					StreamMetrics:        streamMetrics,
+					UnusedActorMetrics:   unusedActorMetrics,
+					MaxConcurrentQueries: maxConcurrentQueries,
+					QueryTimeout:         queryTimeout,
+				},
+			)
+
+			useSnapshots := refreshInterval > 0
+			if useSnapshots {
+				log.Info().Dur("refresh_interval", refreshInterval).Msg("refreshing metrics in the background")
+				go refreshMetrics(airbyteService, refreshInterval)
+			}
 
-			httpServer := newServer(airbyteService, listenAddr)
+			httpServer := newServer(airbyteService, groupByWorkspace, useSnapshots, listenAddr)
 
 			log.Info().Str("addr", listenAddr).Msg("starting HTTP server")
 			return httpServer.ListenAndServe()
@@ -149,6 +192,44 @@ func NewExporterCommand() *cobra.Command {
 		"Listen to this address (host:port)",
 	)
 
+	cmd.Flags().BoolVar(
+		&groupByWorkspace,
+		"group-by-workspace",
+		false,
+		"Add workspace labels to connection, actor and job metrics",
+	)
+	cmd.Flags().BoolVar(
+		&streamMetrics,
+		"stream-metrics",
+		false,
+		"Expose per-stream metrics (high cardinality)",
+	)
+	cmd.Flags().BoolVar(
+		&unusedActorMetrics,
+		"unused-actor-metrics",
+		false,
+		"Expose per-actor metrics for sources and destinations not used by any active connection",
+	)
+
+	cmd.Flags().DurationVar(
+		&refreshInterval,
+		"refresh-interval",
+		0,
+		"Refresh metrics in the background at this interval instead of on every scrape (disabled if 0)",
+	)
+	cmd.Flags().IntVar(
+		&maxConcurrentQueries,
+		"max-concurrent-queries",
+		defaultMaxConcurrentQueries,
+		"Maximum number of database queries to run concurrently (unlimited if 0)",
+	)
+	cmd.Flags().DurationVar(
+		&queryTimeout,
+		"query-timeout",
+		0,
+		"Cancel database queries running for longer than this duration (disabled if 0)",
+	)
+
 	cmd.PersistentFlags().StringVar(
 		&logLevelValue,
 		"log-level",
@@ -7,6 +7,7 @@ package main
 import (
 	"os"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
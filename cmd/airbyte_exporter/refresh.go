@@ -0,0 +1,38 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/botify-labs/airbyte_exporter/v2/internal/airbyte"
+)
+
+// refreshMetrics refreshes the Airbyte metrics snapshot immediately, then at the given interval.
+//
+// Each refresh is canceled if it does not complete within the interval.
+func refreshMetrics(airbyteService *airbyte.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		err := airbyteService.Refresh(ctx)
+		cancel()
+
+		if err != nil {
+			logGatherError(err, "failed to refresh metrics snapshot")
+		} else {
+			log.Debug().Dur("duration_ms", time.Since(start)).Msg("refreshed metrics snapshot")
+		}
+
+		<-ticker.C
+	}
+}
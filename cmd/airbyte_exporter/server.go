@@ -5,7 +5,9 @@
 package main
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/justinas/alice"
@@ -25,6 +27,10 @@ const (
   <p><a href="/metrics">Metrics</a></p>
 </body>
 </html>`
+
+	// scrapeTimeoutOffset is subtracted from the scrape timeout advertised by Prometheus, to leave time
+	// to encode and send metrics.
+	scrapeTimeoutOffset = 500 * time.Millisecond
 )
 
 func accessLogger(r *http.Request, status, size int, dur time.Duration) {
@@ -37,13 +43,58 @@ func accessLogger(r *http.Request, status, size int, dur time.Duration) {
 		Msg("handle request")
 }
 
-func newServer(airbyteService *airbyte.Service, listenAddr string) *http.Server {
-	collector := NewCollector(airbyteService)
-	prometheus.MustRegister(collector)
+// scrapeTimeout returns the scrape timeout advertised by Prometheus in the request headers, minus
+// scrapeTimeoutOffset, if any.
+func scrapeTimeout(r *http.Request) (time.Duration, bool) {
+	header := r.Header.Get("X-Prometheus-Scrape-Timeout-Seconds")
+	if header == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseFloat(header, 64)
+	if err != nil || seconds <= 0 {
+		hlog.FromRequest(r).Warn().Str("scrape_timeout", header).Msg("invalid scrape timeout")
+		return 0, false
+	}
+
+	timeout := time.Duration(seconds * float64(time.Second))
+	if timeout > scrapeTimeoutOffset {
+		timeout -= scrapeTimeoutOffset
+	}
+
+	return timeout, true
+}
+
+// metricsHandler returns a HTTP handler serving Airbyte metrics within the context of each scrape request,
+// along with the metrics of the default registry.
+//
+// Database queries are canceled when the request is canceled, or when the scrape timeout elapses.
+func metricsHandler(collector *collector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if timeout, ok := scrapeTimeout(r); ok {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(requestCollector{collector: collector, ctx: ctx})
+
+		gatherers := prometheus.Gatherers{prometheus.DefaultGatherer, registry}
+
+		promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	})
+}
+
+func newServer(airbyteService *airbyte.Service, groupByWorkspace bool, useSnapshots bool, listenAddr string) *http.Server {
+	collector := NewCollector(airbyteService, groupByWorkspace, useSnapshots)
 
 	router := http.NewServeMux()
 
-	router.Handle("/metrics", promhttp.Handler())
+	router.Handle("/metrics", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, metricsHandler(collector)))
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_, err := w.Write([]byte(webroot))
 		if err != nil {
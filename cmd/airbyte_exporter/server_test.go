@@ -0,0 +1,48 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestScrapeTimeout(t *testing.T) {
+	cases := []struct {
+		tname  string
+		header string
+		want   time.Duration
+		wantOk bool
+	}{
+		{tname: "no header", header: "", want: 0, wantOk: false},
+		{tname: "integer seconds", header: "10", want: 9500 * time.Millisecond, wantOk: true},
+		{tname: "fractional seconds", header: "2.5", want: 2 * time.Second, wantOk: true},
+		{tname: "below the offset", header: "0.2", want: 200 * time.Millisecond, wantOk: true},
+		{tname: "equal to the offset", header: "0.5", want: 500 * time.Millisecond, wantOk: true},
+		{tname: "zero", header: "0", want: 0, wantOk: false},
+		{tname: "negative", header: "-10", want: 0, wantOk: false},
+		{tname: "invalid", header: "ten", want: 0, wantOk: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/metrics", nil)
+			if tc.header != "" {
+				r.Header.Set("X-Prometheus-Scrape-Timeout-Seconds", tc.header)
+			}
+
+			got, ok := scrapeTimeout(r)
+
+			if ok != tc.wantOk {
+				t.Fatalf("want ok %t, got %t", tc.wantOk, ok)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package airbyte
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QueryError reports a database query that failed to gather a group of Airbyte metrics.
+type QueryError struct {
+	Query string
+	Err   error
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("query %s: %s", e.Query, e.Err)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// GatherError reports the database queries that failed while gathering Airbyte metrics.
+type GatherError struct {
+	Errors []*QueryError
+}
+
+func (e *GatherError) Error() string {
+	messages := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%d queries failed: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+func (e *GatherError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
@@ -5,6 +5,7 @@
 package airbyte
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -12,30 +13,156 @@ import (
 
 // Metrics represents available Airbyte metrics.
 type Metrics struct {
+	// Exporter database queries
+	Queries []QueryResult
+
+	// Airbyte instance
+	BuildInfo []BuildInfo
+
 	// Airbyte connections
 	Connections                       []ConnectionCount
+	ConnectionsBrokenReferences       []ConnectionBrokenReferenceCount
+	ConnectionsInfo                   []ConnectionInfo
 	ConnectionsLastSuccessfulSyncAges []ConnectionSyncAge
+	ConnectionsSyncSchedules          []ConnectionSyncSchedule
+	ConnectionsLastSyncs              []ConnectionLastSync
+	ConnectionsConsecutiveFailures    []ConnectionFailureCount
+	ConnectionsNeverSynced            []ConnectionScheduleCount
+	ConnectionsNeverSyncedAges        []ConnectionAge
+	ConnectionsSchemaChangesPending   []ConnectionSchemaChangeCount
+	ConnectionsSchemaChangeAges       []ConnectionSchemaChangeAge
 
 	// Airbyte connectors
-	Sources      []ActorCount
-	Destinations []ActorCount
+	Sources            []ActorCount
+	Destinations       []ActorCount
+	SourcesUnused      []UnusedActorCount
+	DestinationsUnused []UnusedActorCount
+	UnusedActors       []UnusedActor
+
+	// Airbyte connector versions
+	ConnectorVersions     []ConnectorVersionCount
+	ActorsBreakingChanges []ActorBreakingChange
 
 	// Airbyte jobs
 	JobsCompleted []JobCount
 	JobsPending   []JobCount
 	JobsRunning   []JobCount
+
+	// Airbyte pending jobs age
+	JobsPendingAges []JobAgeHistogram
+	JobsQueueWaits  []JobHistogram
+
+	// Airbyte running jobs age
+	JobsRunningAges     []JobAgeHistogram
+	AttemptsRunningAges []JobAgeHistogram
+
+	// Airbyte job and attempt durations
+	JobDurations     []JobHistogram
+	AttemptDurations []JobHistogram
+
+	// Airbyte job attempts
+	JobAttempts    []JobHistogram
+	AttemptsFailed []JobCount
+
+	// Airbyte job attempt failures
+	AttemptFailures []AttemptFailureCount
+
+	// Airbyte stream resets and refreshes
+	ResetJobsCompleted    []ResetJobCount
+	StreamsPendingReset   []StreamPendingResetCount
+	StreamsPendingRefresh []StreamPendingResetCount
+
+	// Airbyte sync volume
+	SyncVolumes []SyncVolume
+
+	// Airbyte streams
+	StreamsLastSyncStats []StreamSyncStats
+	StreamsStatus        []StreamStatus
+}
+
+// JobDurationBuckets holds the upper bounds of the job and attempt duration histogram buckets, in seconds.
+var JobDurationBuckets = []float64{60, 300, 900, 1800, 3600, 7200, 10800, 21600, 43200, 86400}
+
+// JobAgeBuckets holds the upper bounds of the pending and running job age histogram buckets, in seconds.
+var JobAgeBuckets = []float64{300, 900, 1800, 3600, 7200, 21600, 43200, 86400, 172800, 604800}
+
+// JobWaitBuckets holds the upper bounds of the job queue wait time histogram buckets, in seconds.
+var JobWaitBuckets = []float64{10, 30, 60, 300, 900, 1800, 3600, 7200, 21600, 86400}
+
+// JobAttemptsBuckets holds the upper bounds of the job attempts histogram buckets.
+var JobAttemptsBuckets = []float64{1, 2, 3, 4, 5, 10, 20}
+
+// Workspace holds the identity of an Airbyte workspace.
+//
+// For aggregated metrics, its fields are empty unless metrics are grouped by workspace.
+type Workspace struct {
+	WorkspaceID   string `db:"workspace_id"`
+	WorkspaceName string `db:"workspace_name"`
+}
+
+// Gathered returns whether at least one database query succeeded in gathering metrics.
+func (m *Metrics) Gathered() bool {
+	for _, query := range m.Queries {
+		if query.Err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// QueryResult holds the outcome of a database query gathering a group of Airbyte metrics.
+type QueryResult struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// BuildInfo holds the version and deployment ID of an Airbyte instance.
+type BuildInfo struct {
+	Version      string `db:"version"`
+	DeploymentID string `db:"deployment_id"`
 }
 
 // ConnectionCount holds a count of Airbyte connections, grouped by destination connector, source connector and status.
 type ConnectionCount struct {
+	Workspace
+
 	DestinationConnector string `db:"destination"`
 	SourceConnector      string `db:"source"`
 	Status               string `db:"status"`
 	Count                uint   `db:"count"`
 }
 
+// ConnectionBrokenReferenceCount holds the count of active Airbyte connections with a broken reference to an
+// actor or connector.
+type ConnectionBrokenReferenceCount struct {
+	Workspace
+
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	ActorType            string `db:"actor_type"`
+	Reason               string `db:"reason"`
+	Count                uint   `db:"count"`
+}
+
+// ConnectionInfo holds identity information for a single Airbyte connection.
+type ConnectionInfo struct {
+	Workspace
+
+	ID                   string `db:"id"`
+	Name                 string `db:"name"`
+	DestinationName      string `db:"destination_name"`
+	SourceName           string `db:"source_name"`
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	Status               string `db:"status"`
+}
+
 // ConnectionSyncAge holds the age of the last job attempt for a single Airbyte Connection.
 type ConnectionSyncAge struct {
+	Workspace
+
 	ID                   string  `db:"id"`
 	DestinationConnector string  `db:"destination"`
 	SourceConnector      string  `db:"source"`
@@ -48,19 +175,335 @@ func (csa *ConnectionSyncAge) Age() (time.Duration, error) {
 	return time.ParseDuration(fmt.Sprintf("%fh", math.Round(csa.Hours)))
 }
 
+// ConnectionSyncSchedule holds the schedule of a single scheduled Airbyte connection, and the start times
+// of its last successful sync job and of its pending or running sync job.
+//
+// Start times are nil if the connection has no matching sync job.
+type ConnectionSyncSchedule struct {
+	Workspace
+
+	ID                      string        `db:"id"`
+	DestinationConnector    string        `db:"destination"`
+	SourceConnector         string        `db:"source"`
+	ScheduleType            string        `db:"connection_schedule_type"`
+	ScheduleData            *ScheduleData `db:"schedule_data"`
+	CreatedAt               time.Time     `db:"created_at"`
+	LastSuccessfulSyncStart *time.Time    `db:"last_successful_sync_start"`
+	RunningSyncStart        *time.Time    `db:"running_sync_start"`
+}
+
+// NextSync returns the time at which the sync following the last successful sync is scheduled, or the
+// first scheduled sync if the connection has never been synced successfully.
+func (css *ConnectionSyncSchedule) NextSync() (time.Time, error) {
+	from := css.CreatedAt
+	if css.LastSuccessfulSyncStart != nil {
+		from = *css.LastSuccessfulSyncStart
+	}
+
+	switch css.ScheduleType {
+	case "basic_schedule":
+		if css.ScheduleData == nil || css.ScheduleData.BasicSchedule == nil {
+			return time.Time{}, errors.New("missing basic schedule data")
+		}
+		return css.ScheduleData.BasicSchedule.Next(from)
+
+	case "cron":
+		if css.ScheduleData == nil || css.ScheduleData.Cron == nil {
+			return time.Time{}, errors.New("missing cron schedule data")
+		}
+		return css.ScheduleData.Cron.Next(from)
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported schedule type: %q", css.ScheduleType)
+}
+
+// Overdue returns the duration elapsed since the next successful sync was expected, or zero if the next sync
+// is not due yet, or if a sync started since it was due is still pending or running.
+func (css *ConnectionSyncSchedule) Overdue(now time.Time) (time.Duration, error) {
+	nextSync, err := css.NextSync()
+	if err != nil {
+		return 0, err
+	}
+
+	if now.Before(nextSync) {
+		return 0, nil
+	}
+
+	if css.RunningSyncStart != nil && !css.RunningSyncStart.Before(nextSync) {
+		return 0, nil
+	}
+
+	return now.Sub(nextSync), nil
+}
+
+// ConnectionLastSync holds the timestamps and results of the last sync jobs of a single Airbyte connection.
+//
+// Fields are nil if the connection has no matching sync job or attempt.
+type ConnectionLastSync struct {
+	Workspace
+
+	ID                     string     `db:"id"`
+	LastSuccessfulSync     *time.Time `db:"last_successful_sync"`
+	LastFailedSync         *time.Time `db:"last_failed_sync"`
+	LastAttemptStart       *time.Time `db:"last_attempt_start"`
+	LastJobStatus          *string    `db:"last_job_status"`
+	LastJobDurationSeconds *float64   `db:"last_job_duration_seconds"` // no Scanner for time.Duration, storing as a raw value
+}
+
+// ConnectionFailureCount holds the count of failed or cancelled sync jobs since the last successful sync
+// job for a single Airbyte connection.
+type ConnectionFailureCount struct {
+	Workspace
+
+	ID                   string `db:"id"`
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	ScheduleType         string `db:"connection_schedule_type"`
+	Count                uint   `db:"count"`
+}
+
+// ConnectionScheduleCount holds a count of Airbyte connections, grouped by destination connector, source connector
+// and schedule type.
+type ConnectionScheduleCount struct {
+	Workspace
+
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	ScheduleType         string `db:"connection_schedule_type"`
+	Count                uint   `db:"count"`
+}
+
+// ConnectionAge holds the time elapsed since the creation of a single Airbyte connection.
+type ConnectionAge struct {
+	Workspace
+
+	ID                   string  `db:"id"`
+	DestinationConnector string  `db:"destination"`
+	SourceConnector      string  `db:"source"`
+	ScheduleType         string  `db:"connection_schedule_type"`
+	Seconds              float64 `db:"seconds"` // no Scanner for time.Duration, storing as a raw value
+}
+
+// ConnectionSchemaChangeCount holds the count of active Airbyte connections with a pending source schema change.
+type ConnectionSchemaChangeCount struct {
+	Workspace
+
+	DestinationConnector        string `db:"destination"`
+	SourceConnector             string `db:"source"`
+	ChangeType                  string `db:"change_type"`
+	NonBreakingChangePreference string `db:"non_breaking_change_preference"`
+	Count                       uint   `db:"count"`
+}
+
+// ConnectionSchemaChangeAge holds the time elapsed since a pending source schema change was detected
+// for a single Airbyte connection.
+type ConnectionSchemaChangeAge struct {
+	Workspace
+
+	ID                          string  `db:"id"`
+	DestinationConnector        string  `db:"destination"`
+	SourceConnector             string  `db:"source"`
+	ChangeType                  string  `db:"change_type"`
+	NonBreakingChangePreference string  `db:"non_breaking_change_preference"`
+	Seconds                     float64 `db:"seconds"` // no Scanner for time.Duration, storing as a raw value
+}
+
 // ActorCount holds a count of Airbyte actors, grouped by actor connector and status.
 type ActorCount struct {
+	Workspace
+
 	ActorConnector string `db:"actor"`
 	Tombstone      bool   `db:"tombstone"`
 	Count          uint   `db:"count"`
 }
 
+// UnusedActorCount holds a count of Airbyte actors that are not used by any active connection, grouped by
+// actor connector.
+type UnusedActorCount struct {
+	Workspace
+
+	ActorConnector string `db:"actor"`
+	Count          uint   `db:"count"`
+}
+
+// UnusedActor holds the time elapsed since a single Airbyte actor that is not used by any active connection
+// was last used by a job, or since its creation if it was never used.
+type UnusedActor struct {
+	Workspace
+
+	ActorID        string  `db:"actor_id"`
+	ActorName      string  `db:"actor_name"`
+	ActorType      string  `db:"actor_type"`
+	ActorConnector string  `db:"actor"`
+	Seconds        float64 `db:"seconds"` // no Scanner for time.Duration, storing as a raw value
+}
+
+// ConnectorVersionCount holds a count of Airbyte actors, grouped by actor type, actor connector and connector version.
+type ConnectorVersionCount struct {
+	Workspace
+
+	ActorType        string `db:"actor_type"`
+	ActorConnector   string `db:"actor"`
+	DockerRepository string `db:"docker_repository"`
+	DockerImageTag   string `db:"docker_image_tag"`
+	ReleaseStage     string `db:"release_stage"`
+	SupportLevel     string `db:"support_level"`
+	Custom           bool   `db:"custom"`
+	Count            uint   `db:"count"`
+}
+
+// ActorBreakingChange holds a breaking change published for the connector of a single Airbyte actor
+// that is used by an active connection.
+type ActorBreakingChange struct {
+	Workspace
+
+	ActorID         string    `db:"actor_id"`
+	ActorName       string    `db:"actor_name"`
+	ActorType       string    `db:"actor_type"`
+	ActorConnector  string    `db:"actor"`
+	DockerImageTag  string    `db:"docker_image_tag"`
+	Version         string    `db:"version"`
+	UpgradeDeadline time.Time `db:"upgrade_deadline"`
+}
+
+// Affected returns whether the actor's connector version predates the breaking change.
+func (abc *ActorBreakingChange) Affected() (bool, error) {
+	cmp, err := compareVersions(abc.DockerImageTag, abc.Version)
+	if err != nil {
+		return false, err
+	}
+
+	return cmp < 0, nil
+}
+
 // JobCount holds a count of Airbyte jobs, grouped by destination connector, source connector, type and status.
 type JobCount struct {
+	Workspace
+
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	ScheduleType         string `db:"connection_schedule_type"`
+	Type                 string `db:"config_type"`
+	Status               string `db:"status"`
+	Count                uint   `db:"count"`
+}
+
+// ResetJobCount holds the count of Airbyte jobs resetting, refreshing or clearing connection streams,
+// along with the number of streams they affected.
+type ResetJobCount struct {
+	Workspace
+
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	Type                 string `db:"config_type"`
+	Status               string `db:"status"`
+	Count                uint   `db:"count"`
+	Streams              uint64 `db:"streams"`
+}
+
+// StreamPendingResetCount holds the count of Airbyte connection streams waiting to be reset, cleared
+// or refreshed.
+//
+// RefreshType is only set for streams waiting to be refreshed.
+type StreamPendingResetCount struct {
+	Workspace
+
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	RefreshType          string `db:"refresh_type"`
+	Count                uint   `db:"count"`
+}
+
+// Histogram holds observations aggregated by the database.
+type Histogram struct {
+	Count  uint64   `db:"count"`
+	Sum    float64  `db:"sum"`
+	Counts []uint64 `db:"buckets"` // cumulative count of observations for each bucket upper bound
+}
+
+// Buckets returns the cumulative count of observations, indexed by bucket upper bound.
+func (h *Histogram) Buckets(upperBounds []float64) map[float64]uint64 {
+	buckets := make(map[float64]uint64, len(upperBounds))
+
+	for i, upperBound := range upperBounds {
+		if i >= len(h.Counts) {
+			break
+		}
+		buckets[upperBound] = h.Counts[i]
+	}
+
+	return buckets
+}
+
+// JobHistogram holds the distribution of observations on completed Airbyte jobs or attempts,
+// grouped by destination connector, source connector, type and status.
+type JobHistogram struct {
+	Workspace
+
 	DestinationConnector string `db:"destination"`
 	SourceConnector      string `db:"source"`
 	ScheduleType         string `db:"connection_schedule_type"`
 	Type                 string `db:"config_type"`
 	Status               string `db:"status"`
+	Histogram
+}
+
+// JobAgeHistogram holds the distribution of the ages of pending or running Airbyte jobs or attempts (in seconds),
+// grouped by destination connector, source connector, type and status, along with the age of the oldest.
+type JobAgeHistogram struct {
+	JobHistogram
+
+	Oldest float64 `db:"oldest"`
+}
+
+// AttemptFailureCount holds a count of Airbyte job attempt failures, grouped by destination connector, source connector,
+// type, failure origin and failure type.
+type AttemptFailureCount struct {
+	Workspace
+
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	ScheduleType         string `db:"connection_schedule_type"`
+	Type                 string `db:"config_type"`
+	FailureOrigin        string `db:"failure_origin"`
+	FailureType          string `db:"failure_type"`
 	Count                uint   `db:"count"`
 }
+
+// SyncVolume holds the volume of data moved by completed Airbyte jobs, grouped by destination connector, source connector,
+// type and status.
+type SyncVolume struct {
+	Workspace
+
+	DestinationConnector string `db:"destination"`
+	SourceConnector      string `db:"source"`
+	ScheduleType         string `db:"connection_schedule_type"`
+	Type                 string `db:"config_type"`
+	Status               string `db:"status"`
+	BytesEmitted         uint64 `db:"bytes_emitted"`
+	RecordsEmitted       uint64 `db:"records_emitted"`
+	RecordsCommitted     uint64 `db:"records_committed"`
+}
+
+// StreamSyncStats holds the volume of data moved for a single stream of an Airbyte connection
+// by the last attempt of its last completed sync job.
+type StreamSyncStats struct {
+	Workspace
+
+	ConnectionID    string `db:"connection_id"`
+	StreamNamespace string `db:"stream_namespace"`
+	StreamName      string `db:"stream_name"`
+	RecordsEmitted  uint64 `db:"records_emitted"`
+	BytesEmitted    uint64 `db:"bytes_emitted"`
+}
+
+// StreamStatus holds the latest run state of a single stream of an Airbyte connection.
+type StreamStatus struct {
+	Workspace
+
+	ConnectionID       string `db:"connection_id"`
+	StreamNamespace    string `db:"stream_namespace"`
+	StreamName         string `db:"stream_name"`
+	RunState           string `db:"run_state"`
+	IncompleteRunCause string `db:"incomplete_run_cause"`
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package airbyte
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConnectionSyncScheduleOverdue(t *testing.T) {
+	createdAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	daily := &ScheduleData{Cron: &CronSchedule{CronExpression: "0 0 0 * * ?"}}
+	hourly := &ScheduleData{BasicSchedule: &BasicSchedule{TimeUnit: "hours", Units: 1}}
+
+	timePtr := func(t time.Time) *time.Time { return &t }
+
+	cases := []struct {
+		tname    string
+		schedule ConnectionSyncSchedule
+		now      time.Time
+		want     time.Duration
+	}{
+		{
+			tname: "cron, next sync not due yet",
+			schedule: ConnectionSyncSchedule{
+				ScheduleType:            "cron",
+				ScheduleData:            daily,
+				CreatedAt:               createdAt,
+				LastSuccessfulSyncStart: timePtr(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			now:  time.Date(2024, time.January, 15, 23, 0, 0, 0, time.UTC),
+			want: 0,
+		},
+		{
+			tname: "cron, last sync finished after the next scheduled sync",
+			schedule: ConnectionSyncSchedule{
+				ScheduleType:            "cron",
+				ScheduleData:            daily,
+				CreatedAt:               createdAt,
+				LastSuccessfulSyncStart: timePtr(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			now:  time.Date(2024, time.January, 16, 0, 30, 0, 0, time.UTC),
+			want: 30 * time.Minute,
+		},
+		{
+			tname: "cron, sync running since the next scheduled sync",
+			schedule: ConnectionSyncSchedule{
+				ScheduleType:            "cron",
+				ScheduleData:            daily,
+				CreatedAt:               createdAt,
+				LastSuccessfulSyncStart: timePtr(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+				RunningSyncStart:        timePtr(time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC)),
+			},
+			now:  time.Date(2024, time.January, 16, 0, 30, 0, 0, time.UTC),
+			want: 0,
+		},
+		{
+			tname: "cron, sync running since before the next scheduled sync",
+			schedule: ConnectionSyncSchedule{
+				ScheduleType:            "cron",
+				ScheduleData:            daily,
+				CreatedAt:               createdAt,
+				LastSuccessfulSyncStart: timePtr(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+				RunningSyncStart:        timePtr(time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)),
+			},
+			now:  time.Date(2024, time.January, 16, 0, 30, 0, 0, time.UTC),
+			want: 30 * time.Minute,
+		},
+		{
+			tname: "basic schedule, overdue",
+			schedule: ConnectionSyncSchedule{
+				ScheduleType:            "basic_schedule",
+				ScheduleData:            hourly,
+				CreatedAt:               createdAt,
+				LastSuccessfulSyncStart: timePtr(time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)),
+			},
+			now:  time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC),
+			want: time.Hour,
+		},
+		{
+			tname: "never synced successfully",
+			schedule: ConnectionSyncSchedule{
+				ScheduleType: "cron",
+				ScheduleData: daily,
+				CreatedAt:    createdAt,
+			},
+			now:  time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC),
+			want: 24 * time.Hour,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := tc.schedule.Overdue(tc.now)
+			if err != nil {
+				t.Fatalf("want no error, got %q", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("unsupported schedule type", func(t *testing.T) {
+		schedule := ConnectionSyncSchedule{ScheduleType: "manual", CreatedAt: createdAt}
+
+		if _, err := schedule.Overdue(createdAt); err == nil {
+			t.Error("want an error, got none")
+		}
+	})
+}
@@ -6,6 +6,8 @@ package airbyte
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,19 +17,99 @@ import (
 // Airbyte PostgreSQL database.
 type Repository struct {
 	pool *pgxpool.Pool
+
+	groupByWorkspace bool
 }
 
 // NewRepository initializes and returns an Airbyte Repository.
-func NewRepository(pool *pgxpool.Pool) *Repository {
+//
+// If groupByWorkspace is true, connection, actor and job metrics are grouped by workspace.
+func NewRepository(pool *pgxpool.Pool, groupByWorkspace bool) *Repository {
 	return &Repository{
-		pool: pool,
+		pool:             pool,
+		groupByWorkspace: groupByWorkspace,
+	}
+}
+
+// workspaceGrouping holds SQL fragments to select, join and group query rows by Airbyte workspace.
+type workspaceGrouping struct {
+	columns string
+	join    string
+	groupBy string
+}
+
+// workspaceGrouping returns the SQL fragments grouping query rows by the workspace of the given actor,
+// or empty fragments if metrics are not grouped by workspace.
+func (r *Repository) workspaceGrouping(actorAlias string) workspaceGrouping {
+	if !r.groupByWorkspace {
+		return workspaceGrouping{}
+	}
+
+	return workspaceGrouping{
+		columns: "w.id AS workspace_id, w.name AS workspace_name,",
+		join:    fmt.Sprintf("JOIN workspace w ON %s.workspace_id = w.id", actorAlias),
+		groupBy: "w.id, w.name,",
+	}
+}
+
+// pendingSchemaChangeJoin joins the catalog most recently discovered for the source of the connection
+// aliased as c (lc.actor_catalog_id), along with the time it was first discovered (lc.detected_at).
+const pendingSchemaChangeJoin = `
+	LEFT JOIN LATERAL (
+		SELECT e.actor_catalog_id, (
+			SELECT MIN(e2.created_at)
+			FROM  actor_catalog_fetch_event e2
+			WHERE e2.actor_id = e.actor_id
+			AND   e2.actor_catalog_id = e.actor_catalog_id
+		) AS detected_at
+		FROM  actor_catalog_fetch_event e
+		WHERE e.actor_id = c.source_id
+		ORDER BY e.created_at DESC
+		LIMIT 1
+	) lc ON true`
+
+// histogramColumns returns the SQL expressions aggregating the values of expr as a Histogram,
+// using the provided bucket upper bounds.
+func histogramColumns(expr string, upperBounds []float64) string {
+	bucketCounts := make([]string, len(upperBounds))
+
+	for i, upperBound := range upperBounds {
+		bucketCounts[i] = fmt.Sprintf("COUNT(*) FILTER (WHERE %s <= %g)", expr, upperBound)
+	}
+
+	return fmt.Sprintf(
+		"COUNT(*) AS count, COALESCE(SUM(%s), 0) AS sum, ARRAY[%s]::BIGINT[] AS buckets",
+		expr,
+		strings.Join(bucketCounts, ", "),
+	)
+}
+
+// jsonArrayLength returns the SQL expression computing the length of the JSON array at expr,
+// or NULL if expr is not an array.
+func jsonArrayLength(expr string) string {
+	return fmt.Sprintf("CASE WHEN jsonb_typeof(%[1]s) = 'array' THEN jsonb_array_length(%[1]s) END", expr)
+}
+
+// actorBreakingChangeQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ActorBreakingChange.
+func (r *Repository) actorBreakingChangeQuery(ctx context.Context, query string) ([]ActorBreakingChange, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ActorBreakingChange{}, err
+	}
+
+	var actorBreakingChanges []ActorBreakingChange
+	if err := pgxscan.ScanAll(&actorBreakingChanges, rows); err != nil {
+		return []ActorBreakingChange{}, err
 	}
+
+	return actorBreakingChanges, nil
 }
 
 // actorCountQuery provides a helper to run a SQL query that returns rows to be marshaled
 // as a slice of ActorCount.
-func (r *Repository) actorCountQuery(query string) ([]ActorCount, error) {
-	rows, err := r.pool.Query(context.Background(), query)
+func (r *Repository) actorCountQuery(ctx context.Context, query string) ([]ActorCount, error) {
+	rows, err := r.pool.Query(ctx, query)
 	if err != nil {
 		return []ActorCount{}, err
 	}
@@ -40,10 +122,58 @@ func (r *Repository) actorCountQuery(query string) ([]ActorCount, error) {
 	return actorCounts, nil
 }
 
+// buildInfoQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of BuildInfo.
+func (r *Repository) buildInfoQuery(ctx context.Context, query string) ([]BuildInfo, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []BuildInfo{}, err
+	}
+
+	var buildInfos []BuildInfo
+	if err := pgxscan.ScanAll(&buildInfos, rows); err != nil {
+		return []BuildInfo{}, err
+	}
+
+	return buildInfos, nil
+}
+
+// connectionAgeQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionAge.
+func (r *Repository) connectionAgeQuery(ctx context.Context, query string) ([]ConnectionAge, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionAge{}, err
+	}
+
+	var connectionAges []ConnectionAge
+	if err := pgxscan.ScanAll(&connectionAges, rows); err != nil {
+		return []ConnectionAge{}, err
+	}
+
+	return connectionAges, nil
+}
+
+// connectionBrokenReferenceCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionBrokenReferenceCount.
+func (r *Repository) connectionBrokenReferenceCountQuery(ctx context.Context, query string) ([]ConnectionBrokenReferenceCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionBrokenReferenceCount{}, err
+	}
+
+	var connectionBrokenReferenceCounts []ConnectionBrokenReferenceCount
+	if err := pgxscan.ScanAll(&connectionBrokenReferenceCounts, rows); err != nil {
+		return []ConnectionBrokenReferenceCount{}, err
+	}
+
+	return connectionBrokenReferenceCounts, nil
+}
+
 // connectionCountQuery provides a helper to run a SQL query that returns rows to be marshaled
 // as a slice of ConnectionCount.
-func (r *Repository) connectionCountQuery(query string) ([]ConnectionCount, error) {
-	rows, err := r.pool.Query(context.Background(), query)
+func (r *Repository) connectionCountQuery(ctx context.Context, query string) ([]ConnectionCount, error) {
+	rows, err := r.pool.Query(ctx, query)
 	if err != nil {
 		return []ConnectionCount{}, err
 	}
@@ -56,10 +186,106 @@ func (r *Repository) connectionCountQuery(query string) ([]ConnectionCount, erro
 	return connectionCounts, nil
 }
 
+// connectionFailureCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionFailureCount.
+func (r *Repository) connectionFailureCountQuery(ctx context.Context, query string) ([]ConnectionFailureCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionFailureCount{}, err
+	}
+
+	var connectionFailureCounts []ConnectionFailureCount
+	if err := pgxscan.ScanAll(&connectionFailureCounts, rows); err != nil {
+		return []ConnectionFailureCount{}, err
+	}
+
+	return connectionFailureCounts, nil
+}
+
+// connectionInfoQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionInfo.
+func (r *Repository) connectionInfoQuery(ctx context.Context, query string) ([]ConnectionInfo, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionInfo{}, err
+	}
+
+	var connectionsInfo []ConnectionInfo
+	if err := pgxscan.ScanAll(&connectionsInfo, rows); err != nil {
+		return []ConnectionInfo{}, err
+	}
+
+	return connectionsInfo, nil
+}
+
+// connectionLastSyncQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionLastSync.
+func (r *Repository) connectionLastSyncQuery(ctx context.Context, query string) ([]ConnectionLastSync, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionLastSync{}, err
+	}
+
+	var connectionLastSyncs []ConnectionLastSync
+	if err := pgxscan.ScanAll(&connectionLastSyncs, rows); err != nil {
+		return []ConnectionLastSync{}, err
+	}
+
+	return connectionLastSyncs, nil
+}
+
+// connectionScheduleCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionScheduleCount.
+func (r *Repository) connectionScheduleCountQuery(ctx context.Context, query string) ([]ConnectionScheduleCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionScheduleCount{}, err
+	}
+
+	var connectionScheduleCounts []ConnectionScheduleCount
+	if err := pgxscan.ScanAll(&connectionScheduleCounts, rows); err != nil {
+		return []ConnectionScheduleCount{}, err
+	}
+
+	return connectionScheduleCounts, nil
+}
+
+// connectionSchemaChangeAgeQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionSchemaChangeAge.
+func (r *Repository) connectionSchemaChangeAgeQuery(ctx context.Context, query string) ([]ConnectionSchemaChangeAge, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionSchemaChangeAge{}, err
+	}
+
+	var connectionSchemaChangeAges []ConnectionSchemaChangeAge
+	if err := pgxscan.ScanAll(&connectionSchemaChangeAges, rows); err != nil {
+		return []ConnectionSchemaChangeAge{}, err
+	}
+
+	return connectionSchemaChangeAges, nil
+}
+
+// connectionSchemaChangeCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionSchemaChangeCount.
+func (r *Repository) connectionSchemaChangeCountQuery(ctx context.Context, query string) ([]ConnectionSchemaChangeCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionSchemaChangeCount{}, err
+	}
+
+	var connectionSchemaChangeCounts []ConnectionSchemaChangeCount
+	if err := pgxscan.ScanAll(&connectionSchemaChangeCounts, rows); err != nil {
+		return []ConnectionSchemaChangeCount{}, err
+	}
+
+	return connectionSchemaChangeCounts, nil
+}
+
 // connectionSyncAgeQuery provides a helper to run a SQL query that returns rows to be marshaled
 // as a slice of ConnectionSyncAge.
-func (r *Repository) connectionSyncAgeQuery(query string) ([]ConnectionSyncAge, error) {
-	rows, err := r.pool.Query(context.Background(), query)
+func (r *Repository) connectionSyncAgeQuery(ctx context.Context, query string) ([]ConnectionSyncAge, error) {
+	rows, err := r.pool.Query(ctx, query)
 	if err != nil {
 		return []ConnectionSyncAge{}, err
 	}
@@ -72,10 +298,74 @@ func (r *Repository) connectionSyncAgeQuery(query string) ([]ConnectionSyncAge,
 	return connectionSyncAges, nil
 }
 
+// connectionSyncScheduleQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectionSyncSchedule.
+func (r *Repository) connectionSyncScheduleQuery(ctx context.Context, query string) ([]ConnectionSyncSchedule, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectionSyncSchedule{}, err
+	}
+
+	var connectionSyncSchedules []ConnectionSyncSchedule
+	if err := pgxscan.ScanAll(&connectionSyncSchedules, rows); err != nil {
+		return []ConnectionSyncSchedule{}, err
+	}
+
+	return connectionSyncSchedules, nil
+}
+
+// attemptFailureCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of AttemptFailureCount.
+func (r *Repository) attemptFailureCountQuery(ctx context.Context, query string) ([]AttemptFailureCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []AttemptFailureCount{}, err
+	}
+
+	var attemptFailureCounts []AttemptFailureCount
+	if err := pgxscan.ScanAll(&attemptFailureCounts, rows); err != nil {
+		return []AttemptFailureCount{}, err
+	}
+
+	return attemptFailureCounts, nil
+}
+
+// connectorVersionCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ConnectorVersionCount.
+func (r *Repository) connectorVersionCountQuery(ctx context.Context, query string) ([]ConnectorVersionCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ConnectorVersionCount{}, err
+	}
+
+	var connectorVersionCounts []ConnectorVersionCount
+	if err := pgxscan.ScanAll(&connectorVersionCounts, rows); err != nil {
+		return []ConnectorVersionCount{}, err
+	}
+
+	return connectorVersionCounts, nil
+}
+
+// jobAgeHistogramQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of JobAgeHistogram.
+func (r *Repository) jobAgeHistogramQuery(ctx context.Context, query string) ([]JobAgeHistogram, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []JobAgeHistogram{}, err
+	}
+
+	var jobAgeHistograms []JobAgeHistogram
+	if err := pgxscan.ScanAll(&jobAgeHistograms, rows); err != nil {
+		return []JobAgeHistogram{}, err
+	}
+
+	return jobAgeHistograms, nil
+}
+
 // jobCountQuery provides a helper to run a SQL query that returns rows to be marshaled
 // as a slice of JobCount.
-func (r *Repository) jobCountQuery(query string) ([]JobCount, error) {
-	rows, err := r.pool.Query(context.Background(), query)
+func (r *Repository) jobCountQuery(ctx context.Context, query string) ([]JobCount, error) {
+	rows, err := r.pool.Query(ctx, query)
 	if err != nil {
 		return []JobCount{}, err
 	}
@@ -88,26 +378,230 @@ func (r *Repository) jobCountQuery(query string) ([]JobCount, error) {
 	return jobCounts, nil
 }
 
+// jobHistogramQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of JobHistogram.
+func (r *Repository) jobHistogramQuery(ctx context.Context, query string) ([]JobHistogram, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []JobHistogram{}, err
+	}
+
+	var jobHistograms []JobHistogram
+	if err := pgxscan.ScanAll(&jobHistograms, rows); err != nil {
+		return []JobHistogram{}, err
+	}
+
+	return jobHistograms, nil
+}
+
+// resetJobCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of ResetJobCount.
+func (r *Repository) resetJobCountQuery(ctx context.Context, query string) ([]ResetJobCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []ResetJobCount{}, err
+	}
+
+	var resetJobCounts []ResetJobCount
+	if err := pgxscan.ScanAll(&resetJobCounts, rows); err != nil {
+		return []ResetJobCount{}, err
+	}
+
+	return resetJobCounts, nil
+}
+
+// streamPendingResetCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of StreamPendingResetCount.
+func (r *Repository) streamPendingResetCountQuery(ctx context.Context, query string) ([]StreamPendingResetCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []StreamPendingResetCount{}, err
+	}
+
+	var streamPendingResetCounts []StreamPendingResetCount
+	if err := pgxscan.ScanAll(&streamPendingResetCounts, rows); err != nil {
+		return []StreamPendingResetCount{}, err
+	}
+
+	return streamPendingResetCounts, nil
+}
+
+// streamStatusQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of StreamStatus.
+func (r *Repository) streamStatusQuery(ctx context.Context, query string) ([]StreamStatus, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []StreamStatus{}, err
+	}
+
+	var streamStatuses []StreamStatus
+	if err := pgxscan.ScanAll(&streamStatuses, rows); err != nil {
+		return []StreamStatus{}, err
+	}
+
+	return streamStatuses, nil
+}
+
+// streamSyncStatsQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of StreamSyncStats.
+func (r *Repository) streamSyncStatsQuery(ctx context.Context, query string) ([]StreamSyncStats, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []StreamSyncStats{}, err
+	}
+
+	var streamSyncStats []StreamSyncStats
+	if err := pgxscan.ScanAll(&streamSyncStats, rows); err != nil {
+		return []StreamSyncStats{}, err
+	}
+
+	return streamSyncStats, nil
+}
+
+// unusedActorQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of UnusedActor.
+func (r *Repository) unusedActorQuery(ctx context.Context, query string) ([]UnusedActor, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []UnusedActor{}, err
+	}
+
+	var unusedActors []UnusedActor
+	if err := pgxscan.ScanAll(&unusedActors, rows); err != nil {
+		return []UnusedActor{}, err
+	}
+
+	return unusedActors, nil
+}
+
+// unusedActorCountQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of UnusedActorCount.
+func (r *Repository) unusedActorCountQuery(ctx context.Context, query string) ([]UnusedActorCount, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []UnusedActorCount{}, err
+	}
+
+	var unusedActorCounts []UnusedActorCount
+	if err := pgxscan.ScanAll(&unusedActorCounts, rows); err != nil {
+		return []UnusedActorCount{}, err
+	}
+
+	return unusedActorCounts, nil
+}
+
+// syncVolumeQuery provides a helper to run a SQL query that returns rows to be marshaled
+// as a slice of SyncVolume.
+func (r *Repository) syncVolumeQuery(ctx context.Context, query string) ([]SyncVolume, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return []SyncVolume{}, err
+	}
+
+	var syncVolumes []SyncVolume
+	if err := pgxscan.ScanAll(&syncVolumes, rows); err != nil {
+		return []SyncVolume{}, err
+	}
+
+	return syncVolumes, nil
+}
+
+// BuildInfo returns the version and deployment ID of the Airbyte instance, as recorded in its metadata.
+func (r *Repository) BuildInfo(ctx context.Context) ([]BuildInfo, error) {
+	query := `
+	SELECT v.value AS version, COALESCE(d.value, '') AS deployment_id
+	FROM airbyte_metadata v
+	LEFT JOIN airbyte_metadata d ON d.key = 'deployment_id'
+	WHERE v.key = 'airbyte_version'
+	`
+
+	return r.buildInfoQuery(ctx, query)
+}
+
 // ConnectionsCount returns the count of Airbyte connections, grouped by destination, source and status.
-func (r *Repository) ConnectionsCount() ([]ConnectionCount, error) {
+func (r *Repository) ConnectionsCount(ctx context.Context) ([]ConnectionCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, c.status, COUNT(c.status)
+	FROM connection c
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, c.status
+	ORDER BY %[3]s ad1.name, ad2.name, c.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.connectionCountQuery(ctx, query)
+}
+
+// ConnectionsBrokenReferenceCount returns the count of active connections whose source or destination
+// references a deleted actor, or a deleted, deprecated or unsupported connector, grouped by destination,
+// source, the type of the faulty actor and the reason.
+//
+// A connection is counted once for each broken reference.
+func (r *Repository) ConnectionsBrokenReferenceCount(ctx context.Context) ([]ConnectionBrokenReferenceCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, br.actor_type, br.reason, COUNT(c.id)
+	FROM connection c
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	LEFT JOIN actor_definition_version adv1 ON COALESCE(a1.default_version_id, ad1.default_version_id) = adv1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	LEFT JOIN actor_definition_version adv2 ON COALESCE(a2.default_version_id, ad2.default_version_id) = adv2.id
+	%[2]s
+	CROSS JOIN LATERAL (
+		VALUES
+			('destination', 'actor_tombstoned', a1.tombstone),
+			('destination', 'definition_tombstoned', ad1.tombstone),
+			('destination', 'definition_deprecated', adv1.support_state = 'deprecated'),
+			('destination', 'definition_unsupported', adv1.support_state = 'unsupported'),
+			('source', 'actor_tombstoned', a2.tombstone),
+			('source', 'definition_tombstoned', ad2.tombstone),
+			('source', 'definition_deprecated', adv2.support_state = 'deprecated'),
+			('source', 'definition_unsupported', adv2.support_state = 'unsupported')
+	) AS br(actor_type, reason, broken)
+	WHERE c.status = 'active'
+	AND   br.broken
+	GROUP BY %[3]s ad1.name, ad2.name, br.actor_type, br.reason
+	ORDER BY %[3]s ad1.name, ad2.name, br.actor_type, br.reason
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.connectionBrokenReferenceCountQuery(ctx, query)
+}
+
+// ConnectionsInfo returns identity information for each Airbyte connection.
+func (r *Repository) ConnectionsInfo(ctx context.Context) ([]ConnectionInfo, error) {
 	query := `
-	SELECT ad1.name as destination, ad2.name as source, c.status, COUNT(c.status)
+	SELECT c.id, c.name, w.id AS workspace_id, w.name AS workspace_name, a1.name AS destination_name, a2.name AS source_name, ad1.name as destination, ad2.name as source, c.status
 	FROM connection c
 	JOIN actor a1 ON c.destination_id = a1.id
 	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
 	JOIN actor a2 ON c.source_id = a2.id
 	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
-	GROUP BY ad1.name, ad2.name, c.status
-	ORDER BY ad1.name, ad2.name, c.status
+	JOIN workspace w ON a2.workspace_id = w.id
+	ORDER BY w.name, c.name
 	`
 
-	return r.connectionCountQuery(query)
+	return r.connectionInfoQuery(ctx, query)
 }
 
 // ConnectionsLastSuccessfulSyncAge returns the age of the last successful sync job attempt
 // for active connections.
-func (r *Repository) ConnectionsLastSuccessfulSyncAge() ([]ConnectionSyncAge, error) {
-	query := `
+func (r *Repository) ConnectionsLastSuccessfulSyncAge(ctx context.Context) ([]ConnectionSyncAge, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
 	WITH j AS (
 		SELECT scope, max(updated_at) AS updated_at
 		FROM  jobs
@@ -115,85 +609,579 @@ func (r *Repository) ConnectionsLastSuccessfulSyncAge() ([]ConnectionSyncAge, er
 		AND   status = 'succeeded'
 		GROUP BY scope
 	)
-	SELECT c.id, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, ad1.name as destination, ad2.name as source, EXTRACT(EPOCH FROM AGE(NOW(), j.updated_at))/3600 as hours
+	SELECT %[1]s c.id, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, ad1.name as destination, ad2.name as source, EXTRACT(EPOCH FROM AGE(NOW(), j.updated_at))/3600 as hours
 	FROM connection c
 	JOIN j ON j.scope = CAST(c.id AS VARCHAR(255))
 	JOIN actor a1 ON c.destination_id = a1.id
 	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
 	JOIN actor a2 ON c.source_id = a2.id
 	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
 	WHERE c.status = 'active'
-	`
+	`,
+		ws.columns,
+		ws.join,
+	)
+
+	return r.connectionSyncAgeQuery(ctx, query)
+}
+
+// ConnectionsSyncSchedule returns the schedule, the creation time and the start times of the last successful
+// and of the running sync jobs for active connections running on a schedule.
+func (r *Repository) ConnectionsSyncSchedule(ctx context.Context) ([]ConnectionSyncSchedule, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH j AS (
+		SELECT scope,
+		       max(created_at) FILTER (WHERE status = 'succeeded') AS last_successful_sync_start,
+		       max(created_at) FILTER (WHERE status IN ('pending', 'running', 'incomplete')) AS running_sync_start
+		FROM  jobs
+		WHERE config_type = 'sync'
+		GROUP BY scope
+	)
+	SELECT %[1]s c.id, c.schedule_type AS connection_schedule_type, c.schedule_data, ad1.name as destination, ad2.name as source, c.created_at, j.last_successful_sync_start, j.running_sync_start
+	FROM connection c
+	LEFT JOIN j ON j.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	WHERE c.status = 'active'
+	AND   c.schedule_type IN ('basic_schedule', 'cron')
+	`,
+		ws.columns,
+		ws.join,
+	)
+
+	return r.connectionSyncScheduleQuery(ctx, query)
+}
+
+// ConnectionsLastSync returns the timestamps of the last successful sync job, last failed sync job and last
+// sync attempt, and the status and duration of the last completed sync job for non-deleted connections.
+func (r *Repository) ConnectionsLastSync(ctx context.Context) ([]ConnectionLastSync, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH j AS (
+		SELECT scope,
+		       max(updated_at) FILTER (WHERE status = 'succeeded') AS last_successful_sync,
+		       max(updated_at) FILTER (WHERE status = 'failed') AS last_failed_sync
+		FROM  jobs
+		WHERE config_type = 'sync'
+		GROUP BY scope
+	),
+	lj AS (
+		SELECT DISTINCT ON (scope) scope, status, EXTRACT(EPOCH FROM (updated_at - COALESCE(started_at, created_at)))::DOUBLE PRECISION AS duration_seconds
+		FROM  jobs
+		WHERE config_type = 'sync'
+		AND   status IN ('cancelled', 'failed', 'succeeded')
+		ORDER BY scope, created_at DESC
+	),
+	la AS (
+		SELECT j.scope, max(att.created_at) AS created_at
+		FROM  attempts att
+		JOIN  jobs j ON att.job_id = j.id
+		WHERE j.config_type = 'sync'
+		GROUP BY j.scope
+	)
+	SELECT %[1]s c.id, j.last_successful_sync, j.last_failed_sync, la.created_at AS last_attempt_start, CAST(lj.status AS VARCHAR) AS last_job_status, lj.duration_seconds AS last_job_duration_seconds
+	FROM connection c
+	JOIN actor a2 ON c.source_id = a2.id
+	%[2]s
+	LEFT JOIN j ON j.scope = CAST(c.id AS VARCHAR(255))
+	LEFT JOIN lj ON lj.scope = CAST(c.id AS VARCHAR(255))
+	LEFT JOIN la ON la.scope = CAST(c.id AS VARCHAR(255))
+	WHERE c.status <> 'deprecated'
+	`,
+		ws.columns,
+		ws.join,
+	)
+
+	return r.connectionLastSyncQuery(ctx, query)
+}
+
+// ConnectionsConsecutiveFailuresCount returns the count of failed or cancelled sync jobs since the last
+// successful sync job for active connections.
+func (r *Repository) ConnectionsConsecutiveFailuresCount(ctx context.Context) ([]ConnectionFailureCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH ls AS (
+		SELECT scope, max(created_at) AS created_at
+		FROM  jobs
+		WHERE config_type = 'sync'
+		AND   status = 'succeeded'
+		GROUP BY scope
+	)
+	SELECT %[1]s c.id, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, ad1.name as destination, ad2.name as source, COUNT(j.id)
+	FROM connection c
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	LEFT JOIN ls ON ls.scope = CAST(c.id AS VARCHAR(255))
+	LEFT JOIN jobs j ON j.scope = CAST(c.id AS VARCHAR(255))
+	          AND j.config_type = 'sync'
+	          AND j.status IN ('cancelled', 'failed')
+	          AND (ls.created_at IS NULL OR j.created_at > ls.created_at)
+	WHERE c.status = 'active'
+	GROUP BY %[3]s c.id, connection_schedule_type, ad1.name, ad2.name
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.connectionFailureCountQuery(ctx, query)
+}
+
+// ConnectionsNeverSyncedCount returns the count of active connections without any successful sync job,
+// grouped by destination, source and schedule type.
+func (r *Repository) ConnectionsNeverSyncedCount(ctx context.Context) ([]ConnectionScheduleCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, COUNT(c.id)
+	FROM connection c
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	WHERE c.status = 'active'
+	AND   NOT EXISTS (
+		SELECT 1
+		FROM  jobs j
+		WHERE j.scope = CAST(c.id AS VARCHAR(255))
+		AND   j.config_type = 'sync'
+		AND   j.status = 'succeeded'
+	)
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.connectionScheduleCountQuery(ctx, query)
+}
+
+// ConnectionsNeverSyncedAge returns the time elapsed since the creation of active connections without
+// any successful sync job.
+func (r *Repository) ConnectionsNeverSyncedAge(ctx context.Context) ([]ConnectionAge, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s c.id, ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, EXTRACT(EPOCH FROM (NOW() - c.created_at))::DOUBLE PRECISION AS seconds
+	FROM connection c
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	WHERE c.status = 'active'
+	AND   NOT EXISTS (
+		SELECT 1
+		FROM  jobs j
+		WHERE j.scope = CAST(c.id AS VARCHAR(255))
+		AND   j.config_type = 'sync'
+		AND   j.status = 'succeeded'
+	)
+	`,
+		ws.columns,
+		ws.join,
+	)
+
+	return r.connectionAgeQuery(ctx, query)
+}
+
+// ConnectionsSchemaChangePendingCount returns the count of active connections whose source schema changed
+// since the connection's catalog was last updated, grouped by destination, source, change type and
+// non-breaking change preference.
+func (r *Repository) ConnectionsSchemaChangePendingCount(ctx context.Context) ([]ConnectionSchemaChangeCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source,
+	       CASE WHEN c.breaking_change THEN 'breaking' ELSE 'non_breaking' END AS change_type,
+	       CAST(c.non_breaking_change_preference AS VARCHAR) AS non_breaking_change_preference,
+	       COUNT(c.id)
+	FROM connection c
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	%[4]s
+	WHERE c.status = 'active'
+	AND   (c.breaking_change OR lc.actor_catalog_id <> c.source_catalog_id)
+	GROUP BY %[3]s ad1.name, ad2.name, change_type, c.non_breaking_change_preference
+	ORDER BY %[3]s ad1.name, ad2.name, change_type, c.non_breaking_change_preference
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		pendingSchemaChangeJoin,
+	)
+
+	return r.connectionSchemaChangeCountQuery(ctx, query)
+}
+
+// ConnectionsSchemaChangePendingAge returns the time elapsed since a source schema change was detected
+// for active connections whose catalog was not updated since.
+//
+// Breaking changes detected before the source's latest catalog was discovered are reported as of the time
+// the connection was last updated.
+func (r *Repository) ConnectionsSchemaChangePendingAge(ctx context.Context) ([]ConnectionSchemaChangeAge, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s c.id, ad1.name as destination, ad2.name as source,
+	       CASE WHEN c.breaking_change THEN 'breaking' ELSE 'non_breaking' END AS change_type,
+	       CAST(c.non_breaking_change_preference AS VARCHAR) AS non_breaking_change_preference,
+	       EXTRACT(EPOCH FROM (NOW() - COALESCE(lc.detected_at, c.updated_at)))::DOUBLE PRECISION AS seconds
+	FROM connection c
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	%[3]s
+	WHERE c.status = 'active'
+	AND   (c.breaking_change OR lc.actor_catalog_id <> c.source_catalog_id)
+	`,
+		ws.columns,
+		ws.join,
+		pendingSchemaChangeJoin,
+	)
 
-	return r.connectionSyncAgeQuery(query)
+	return r.connectionSchemaChangeAgeQuery(ctx, query)
 }
 
 // SourcesCount returns the count of Airbyte sources, grouped by actor connector and status.
-func (r *Repository) SourcesCount() ([]ActorCount, error) {
-	query := `
-	SELECT ad.name as actor, a.tombstone, COUNT(a.tombstone)
+func (r *Repository) SourcesCount(ctx context.Context) ([]ActorCount, error) {
+	ws := r.workspaceGrouping("a")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad.name as actor, a.tombstone, COUNT(a.tombstone)
 	FROM actor a
 	JOIN actor_definition ad ON a.actor_definition_id = ad.id
+	%[2]s
 	WHERE a.actor_type = 'source'
-	GROUP BY ad.name, a.tombstone
-	ORDER BY ad.name, a.tombstone
-	`
-	return r.actorCountQuery(query)
+	GROUP BY %[3]s ad.name, a.tombstone
+	ORDER BY %[3]s ad.name, a.tombstone
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+	return r.actorCountQuery(ctx, query)
 }
 
 // DestinationsCount returns the count of Airbyte sources, grouped by actor connector and status.
-func (r *Repository) DestinationsCount() ([]ActorCount, error) {
-	query := `
-	SELECT ad.name as actor, a.tombstone, COUNT(a.tombstone)
+func (r *Repository) DestinationsCount(ctx context.Context) ([]ActorCount, error) {
+	ws := r.workspaceGrouping("a")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad.name as actor, a.tombstone, COUNT(a.tombstone)
 	FROM actor a
 	JOIN actor_definition ad ON a.actor_definition_id = ad.id
+	%[2]s
 	WHERE a.actor_type = 'destination'
-	GROUP BY ad.name, a.tombstone
-	ORDER BY ad.name, a.tombstone
-	`
-	return r.actorCountQuery(query)
+	GROUP BY %[3]s ad.name, a.tombstone
+	ORDER BY %[3]s ad.name, a.tombstone
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+	return r.actorCountQuery(ctx, query)
+}
+
+// SourcesUnusedCount returns the count of non-deleted Airbyte sources that are not used by any active connection,
+// grouped by actor connector.
+func (r *Repository) SourcesUnusedCount(ctx context.Context) ([]UnusedActorCount, error) {
+	ws := r.workspaceGrouping("a")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad.name as actor, COUNT(a.id)
+	FROM actor a
+	JOIN actor_definition ad ON a.actor_definition_id = ad.id
+	%[2]s
+	WHERE a.actor_type = 'source'
+	AND   a.tombstone = false
+	AND   NOT EXISTS (
+		SELECT 1
+		FROM  connection c
+		WHERE c.status = 'active'
+		AND   c.source_id = a.id
+	)
+	GROUP BY %[3]s ad.name
+	ORDER BY %[3]s ad.name
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.unusedActorCountQuery(ctx, query)
+}
+
+// DestinationsUnusedCount returns the count of non-deleted Airbyte destinations that are not used by any active
+// connection, grouped by actor connector.
+func (r *Repository) DestinationsUnusedCount(ctx context.Context) ([]UnusedActorCount, error) {
+	ws := r.workspaceGrouping("a")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad.name as actor, COUNT(a.id)
+	FROM actor a
+	JOIN actor_definition ad ON a.actor_definition_id = ad.id
+	%[2]s
+	WHERE a.actor_type = 'destination'
+	AND   a.tombstone = false
+	AND   NOT EXISTS (
+		SELECT 1
+		FROM  connection c
+		WHERE c.status = 'active'
+		AND   c.destination_id = a.id
+	)
+	GROUP BY %[3]s ad.name
+	ORDER BY %[3]s ad.name
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.unusedActorCountQuery(ctx, query)
+}
+
+// UnusedActorsAge returns the time elapsed since non-deleted Airbyte actors that are not used by any active
+// connection were last used by a job, or since their creation if they were never used.
+func (r *Repository) UnusedActorsAge(ctx context.Context) ([]UnusedActor, error) {
+	ws := r.workspaceGrouping("a")
+	query := fmt.Sprintf(`
+	WITH j AS (
+		SELECT scope, max(updated_at) AS updated_at
+		FROM  jobs
+		GROUP BY scope
+	),
+	u AS (
+		SELECT c.source_id AS actor_id, j.updated_at
+		FROM  connection c
+		JOIN  j ON j.scope = CAST(c.id AS VARCHAR(255))
+		UNION ALL
+		SELECT c.destination_id AS actor_id, j.updated_at
+		FROM  connection c
+		JOIN  j ON j.scope = CAST(c.id AS VARCHAR(255))
+	)
+	SELECT %[1]s a.id AS actor_id, a.name AS actor_name, a.actor_type, ad.name as actor,
+	       EXTRACT(EPOCH FROM (NOW() - COALESCE(MAX(u.updated_at), a.created_at)))::DOUBLE PRECISION AS seconds
+	FROM actor a
+	JOIN actor_definition ad ON a.actor_definition_id = ad.id
+	%[2]s
+	LEFT JOIN u ON u.actor_id = a.id
+	WHERE a.tombstone = false
+	AND   NOT EXISTS (
+		SELECT 1
+		FROM  connection c
+		WHERE c.status = 'active'
+		AND   (c.source_id = a.id OR c.destination_id = a.id)
+	)
+	GROUP BY %[3]s a.id, ad.name
+	ORDER BY %[3]s a.actor_type, ad.name, a.name
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.unusedActorQuery(ctx, query)
+}
+
+// ConnectorVersionsCount returns the count of non-deleted Airbyte actors, grouped by actor type, actor connector
+// and the connector version they run.
+func (r *Repository) ConnectorVersionsCount(ctx context.Context) ([]ConnectorVersionCount, error) {
+	ws := r.workspaceGrouping("a")
+	query := fmt.Sprintf(`
+	SELECT %[1]s a.actor_type, ad.name as actor, adv.docker_repository, adv.docker_image_tag,
+	       COALESCE(CAST(adv.release_stage AS VARCHAR), 'unknown') AS release_stage,
+	       COALESCE(CAST(adv.support_level AS VARCHAR), 'unknown') AS support_level,
+	       ad.custom, COUNT(a.id)
+	FROM actor a
+	JOIN actor_definition ad ON a.actor_definition_id = ad.id
+	JOIN actor_definition_version adv ON COALESCE(a.default_version_id, ad.default_version_id) = adv.id
+	%[2]s
+	WHERE a.tombstone = false
+	GROUP BY %[3]s a.actor_type, ad.name, adv.docker_repository, adv.docker_image_tag, adv.release_stage, adv.support_level, ad.custom
+	ORDER BY %[3]s a.actor_type, ad.name, adv.docker_repository, adv.docker_image_tag
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.connectorVersionCountQuery(ctx, query)
+}
+
+// ActorsBreakingChanges returns the breaking changes published for the connectors of non-deleted Airbyte actors
+// used by active connections, along with the connector version each actor runs.
+//
+// Custom connectors, and connector versions that are not tagged with a semantic version, are skipped.
+func (r *Repository) ActorsBreakingChanges(ctx context.Context) ([]ActorBreakingChange, error) {
+	ws := r.workspaceGrouping("a")
+	query := fmt.Sprintf(`
+	SELECT %[1]s a.id AS actor_id, a.name AS actor_name, a.actor_type, ad.name as actor, adv.docker_image_tag, bc.version, bc.upgrade_deadline
+	FROM actor a
+	JOIN actor_definition ad ON a.actor_definition_id = ad.id
+	JOIN actor_definition_version adv ON COALESCE(a.default_version_id, ad.default_version_id) = adv.id
+	JOIN actor_definition_breaking_change bc ON bc.actor_definition_id = ad.id
+	%[2]s
+	WHERE a.tombstone = false
+	AND   ad.custom = false
+	AND   adv.docker_image_tag ~ '^v?[0-9]+\.[0-9]+\.[0-9]+([-+].+)?$'
+	AND   EXISTS (
+		SELECT 1
+		FROM  connection c
+		WHERE c.status = 'active'
+		AND   (c.source_id = a.id OR c.destination_id = a.id)
+	)
+	ORDER BY a.actor_type, ad.name, a.name, bc.version
+	`,
+		ws.columns,
+		ws.join,
+	)
+
+	return r.actorBreakingChangeQuery(ctx, query)
 }
 
 // JobsCompletedCount returns the count of completed Airbyte jobs, grouped by destination, source, type and status.
-func (r *Repository) JobsCompletedCount() ([]JobCount, error) {
-	query := `
-	SELECT ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, j.status, COUNT(j.status)
+func (r *Repository) JobsCompletedCount(ctx context.Context) ([]JobCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, j.status, COUNT(j.status)
 	FROM jobs j
 	JOIN connection c ON j.scope = CAST(c.id AS VARCHAR(255))
 	JOIN actor a1 ON c.destination_id = a1.id
 	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
 	JOIN actor a2 ON c.source_id = a2.id
 	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
 	WHERE j.status IN ('cancelled', 'failed', 'succeeded')
-	GROUP BY ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
-	ORDER BY ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
-	`
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
 
-	return r.jobCountQuery(query)
+	return r.jobCountQuery(ctx, query)
+}
+
+// ResetJobsCompletedCount returns the count of completed Airbyte jobs resetting, refreshing or clearing
+// connection streams, along with the number of streams they affected, grouped by destination, source, type
+// and status.
+//
+// Reset and clear jobs that do not list the streams to reset affect all the streams of the connection's catalog.
+func (r *Repository) ResetJobsCompletedCount(ctx context.Context) ([]ResetJobCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, j.config_type, j.status, COUNT(j.id),
+	       COALESCE(SUM(COALESCE(%[4]s, %[5]s, %[6]s, 0)), 0) AS streams
+	FROM jobs j
+	JOIN connection c ON j.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	WHERE j.config_type IN ('reset_connection', 'refresh', 'clear')
+	AND   j.status IN ('cancelled', 'failed', 'succeeded')
+	GROUP BY %[3]s ad1.name, ad2.name, j.config_type, j.status
+	ORDER BY %[3]s ad1.name, ad2.name, j.config_type, j.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		jsonArrayLength("j.config->'resetConnection'->'resetSourceConfiguration'->'streamsToReset'"),
+		jsonArrayLength("j.config->'resetConnection'->'configuredAirbyteCatalog'->'streams'"),
+		jsonArrayLength("j.config->'refresh'->'streamsToRefresh'"),
+	)
+
+	return r.resetJobCountQuery(ctx, query)
+}
+
+// StreamsPendingResetCount returns the count of connection streams waiting to be reset or cleared,
+// grouped by destination and source.
+func (r *Repository) StreamsPendingResetCount(ctx context.Context) ([]StreamPendingResetCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COUNT(sr.id)
+	FROM stream_reset sr
+	JOIN connection c ON sr.connection_id = c.id
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name
+	ORDER BY %[3]s ad1.name, ad2.name
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.streamPendingResetCountQuery(ctx, query)
+}
+
+// StreamsPendingRefreshCount returns the count of connection streams waiting to be refreshed,
+// grouped by destination, source and refresh type.
+func (r *Repository) StreamsPendingRefreshCount(ctx context.Context) ([]StreamPendingResetCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, LOWER(CAST(sr.refresh_type AS VARCHAR)) AS refresh_type, COUNT(sr.id)
+	FROM stream_refreshes sr
+	JOIN connection c ON sr.connection_id = c.id
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, sr.refresh_type
+	ORDER BY %[3]s ad1.name, ad2.name, sr.refresh_type
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.streamPendingResetCountQuery(ctx, query)
 }
 
 // JobsPendingCount returns the count of pending Airbyte jobs, grouped by destination, source and type.
-func (r *Repository) JobsPendingCount() ([]JobCount, error) {
-	query := `
-	SELECT ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, j.status, COUNT(j.status)
+func (r *Repository) JobsPendingCount(ctx context.Context) ([]JobCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, j.status, COUNT(j.status)
 	FROM jobs j
 	JOIN connection c ON CAST(c.id AS VARCHAR(255)) = j.scope
 	JOIN actor a1 ON c.destination_id = a1.id
 	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
 	JOIN actor a2 ON c.source_id = a2.id
 	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
 	WHERE j.status = 'pending'
-	GROUP BY ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
-	ORDER BY ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
-	`
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
 
-	return r.jobCountQuery(query)
+	return r.jobCountQuery(ctx, query)
 }
 
 // JobsRunningCount returns the count of running Airbyte jobs, grouped by destination, source and type.
-func (r *Repository) JobsRunningCount() ([]JobCount, error) {
-	query := `
-	SELECT ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, j.status, COUNT(j.status)
+func (r *Repository) JobsRunningCount(ctx context.Context) ([]JobCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, j.status, COUNT(j.status)
 	FROM jobs j
 	JOIN attempts att ON att.job_id = j.id
 	JOIN connection c ON j.scope = CAST(c.id AS VARCHAR(255))
@@ -201,11 +1189,371 @@ func (r *Repository) JobsRunningCount() ([]JobCount, error) {
 	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
 	JOIN actor a2 ON c.source_id = a2.id
 	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
 	WHERE j.status = 'running'
 	AND   att.status = 'running'
-	GROUP BY ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
-	ORDER BY ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
-	`
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.jobCountQuery(ctx, query)
+}
+
+// JobsPendingAge returns the distribution of the ages of pending Airbyte jobs, grouped by destination, source
+// and type, along with the age of the oldest pending job.
+func (r *Repository) JobsPendingAge(ctx context.Context) ([]JobAgeHistogram, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH d AS (
+		SELECT scope, config_type, status, EXTRACT(EPOCH FROM (NOW() - created_at))::DOUBLE PRECISION AS seconds
+		FROM  jobs
+		WHERE status = 'pending'
+	)
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, d.config_type, d.status, %[4]s, MAX(d.seconds) AS oldest
+	FROM d
+	JOIN connection c ON d.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		histogramColumns("d.seconds", JobWaitBuckets),
+	)
+
+	return r.jobAgeHistogramQuery(ctx, query)
+}
+
+// JobsCompletedQueueWait returns the distribution of the time completed Airbyte jobs waited between their
+// creation and the creation of their first attempt, grouped by destination, source, type and status.
+func (r *Repository) JobsCompletedQueueWait(ctx context.Context) ([]JobHistogram, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH d AS (
+		SELECT j.scope, j.config_type, j.status, EXTRACT(EPOCH FROM (MIN(att.created_at) - j.created_at))::DOUBLE PRECISION AS seconds
+		FROM  jobs j
+		JOIN  attempts att ON att.job_id = j.id
+		WHERE j.status IN ('cancelled', 'failed', 'succeeded')
+		GROUP BY j.id
+	)
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, d.config_type, d.status, %[4]s
+	FROM d
+	JOIN connection c ON d.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		histogramColumns("d.seconds", JobWaitBuckets),
+	)
+
+	return r.jobHistogramQuery(ctx, query)
+}
+
+// JobsRunningAge returns the distribution of the ages of running Airbyte jobs, grouped by destination, source
+// and type, along with the age of the oldest running job.
+func (r *Repository) JobsRunningAge(ctx context.Context) ([]JobAgeHistogram, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH d AS (
+		SELECT scope, config_type, status, EXTRACT(EPOCH FROM (NOW() - created_at))::DOUBLE PRECISION AS seconds
+		FROM  jobs
+		WHERE status = 'running'
+	)
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, d.config_type, d.status, %[4]s, MAX(d.seconds) AS oldest
+	FROM d
+	JOIN connection c ON d.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		histogramColumns("d.seconds", JobAgeBuckets),
+	)
+
+	return r.jobAgeHistogramQuery(ctx, query)
+}
+
+// AttemptsRunningAge returns the distribution of the ages of the current attempts of running Airbyte jobs,
+// grouped by destination, source and type, along with the age of the oldest running attempt.
+func (r *Repository) AttemptsRunningAge(ctx context.Context) ([]JobAgeHistogram, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH d AS (
+		SELECT j.scope, j.config_type, j.status, EXTRACT(EPOCH FROM (NOW() - att.created_at))::DOUBLE PRECISION AS seconds
+		FROM  jobs j
+		JOIN  attempts att ON att.job_id = j.id
+		WHERE j.status = 'running'
+		AND   att.status = 'running'
+	)
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, d.config_type, d.status, %[4]s, MAX(d.seconds) AS oldest
+	FROM d
+	JOIN connection c ON d.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		histogramColumns("d.seconds", JobAgeBuckets),
+	)
+
+	return r.jobAgeHistogramQuery(ctx, query)
+}
+
+// JobsCompletedDuration returns the distribution of the wall-clock durations of completed Airbyte jobs,
+// grouped by destination, source, type and status.
+func (r *Repository) JobsCompletedDuration(ctx context.Context) ([]JobHistogram, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH d AS (
+		SELECT scope, config_type, status, EXTRACT(EPOCH FROM (updated_at - COALESCE(started_at, created_at)))::DOUBLE PRECISION AS seconds
+		FROM  jobs
+		WHERE status IN ('cancelled', 'failed', 'succeeded')
+	)
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, d.config_type, d.status, %[4]s
+	FROM d
+	JOIN connection c ON d.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		histogramColumns("d.seconds", JobDurationBuckets),
+	)
+
+	return r.jobHistogramQuery(ctx, query)
+}
+
+// AttemptsCompletedDuration returns the distribution of the wall-clock durations of completed Airbyte job attempts,
+// grouped by destination, source, job type and attempt status.
+func (r *Repository) AttemptsCompletedDuration(ctx context.Context) ([]JobHistogram, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH d AS (
+		SELECT j.scope, j.config_type, att.status, EXTRACT(EPOCH FROM (COALESCE(att.ended_at, att.updated_at) - att.created_at))::DOUBLE PRECISION AS seconds
+		FROM  attempts att
+		JOIN  jobs j ON att.job_id = j.id
+		WHERE att.status IN ('failed', 'succeeded')
+	)
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, d.config_type, d.status, %[4]s
+	FROM d
+	JOIN connection c ON d.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		histogramColumns("d.seconds", JobDurationBuckets),
+	)
+
+	return r.jobHistogramQuery(ctx, query)
+}
+
+// JobsCompletedAttempts returns the distribution of the number of attempts of completed Airbyte jobs,
+// grouped by destination, source, type and status.
+func (r *Repository) JobsCompletedAttempts(ctx context.Context) ([]JobHistogram, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH d AS (
+		SELECT j.scope, j.config_type, j.status, COUNT(att.id)::DOUBLE PRECISION AS attempts
+		FROM  jobs j
+		JOIN  attempts att ON att.job_id = j.id
+		WHERE j.status IN ('cancelled', 'failed', 'succeeded')
+		GROUP BY j.id
+	)
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, d.config_type, d.status, %[4]s
+	FROM d
+	JOIN connection c ON d.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, d.config_type, d.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+		histogramColumns("d.attempts", JobAttemptsBuckets),
+	)
+
+	return r.jobHistogramQuery(ctx, query)
+}
+
+// AttemptsFailedCount returns the count of failed Airbyte job attempts, grouped by destination, source and type.
+func (r *Repository) AttemptsFailedCount(ctx context.Context) ([]JobCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, att.status, COUNT(att.status)
+	FROM attempts att
+	JOIN jobs j ON att.job_id = j.id
+	JOIN connection c ON j.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	WHERE att.status = 'failed'
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, att.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, att.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.jobCountQuery(ctx, query)
+}
+
+// AttemptFailuresCount returns the count of failures reported in the failure summaries of Airbyte job attempts,
+// grouped by destination, source, type, failure origin and failure type.
+func (r *Repository) AttemptFailuresCount(ctx context.Context) ([]AttemptFailureCount, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type,
+	       COALESCE(f.failure->>'failureOrigin', 'unknown') AS failure_origin,
+	       COALESCE(f.failure->>'failureType', 'unknown') AS failure_type,
+	       COUNT(*)
+	FROM attempts att
+	CROSS JOIN LATERAL jsonb_array_elements(
+		CASE WHEN jsonb_typeof(att.failure_summary->'failures') = 'array' THEN att.failure_summary->'failures' ELSE '[]'::JSONB END
+	) AS f(failure)
+	JOIN jobs j ON att.job_id = j.id
+	JOIN connection c ON j.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, failure_origin, failure_type
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, failure_origin, failure_type
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.attemptFailureCountQuery(ctx, query)
+}
+
+// SyncVolumes returns the bytes and records synced by completed Airbyte jobs, as reported by their attempts'
+// sync statistics, grouped by destination, source, type and status.
+func (r *Repository) SyncVolumes(ctx context.Context) ([]SyncVolume, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT %[1]s ad1.name as destination, ad2.name as source, COALESCE(c.schedule_type, 'manual') AS connection_schedule_type, j.config_type, j.status,
+	       COALESCE(SUM(ss.bytes_emitted), 0)::BIGINT AS bytes_emitted,
+	       COALESCE(SUM(ss.records_emitted), 0)::BIGINT AS records_emitted,
+	       COALESCE(SUM(ss.records_committed), 0)::BIGINT AS records_committed
+	FROM jobs j
+	JOIN attempts att ON att.job_id = j.id
+	JOIN sync_stats ss ON ss.attempt_id = att.id
+	JOIN connection c ON j.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a1 ON c.destination_id = a1.id
+	JOIN actor_definition ad1 ON a1.actor_definition_id = ad1.id
+	JOIN actor a2 ON c.source_id = a2.id
+	JOIN actor_definition ad2 ON a2.actor_definition_id = ad2.id
+	%[2]s
+	WHERE j.status IN ('cancelled', 'failed', 'succeeded')
+	GROUP BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	ORDER BY %[3]s ad1.name, ad2.name, connection_schedule_type, j.config_type, j.status
+	`,
+		ws.columns,
+		ws.join,
+		ws.groupBy,
+	)
+
+	return r.syncVolumeQuery(ctx, query)
+}
+
+// StreamsLastSyncStats returns the records and bytes emitted for each stream of active connections,
+// by the last attempt of their last completed sync job.
+func (r *Repository) StreamsLastSyncStats(ctx context.Context) ([]StreamSyncStats, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	WITH latest AS (
+		SELECT DISTINCT ON (j.scope) j.scope, att.id AS attempt_id
+		FROM  jobs j
+		JOIN  attempts att ON att.job_id = j.id
+		WHERE j.config_type = 'sync'
+		AND   j.status IN ('cancelled', 'failed', 'succeeded')
+		ORDER BY j.scope, j.created_at DESC, att.attempt_number DESC
+	)
+	SELECT %[1]s c.id AS connection_id, COALESCE(ss.stream_namespace, '') AS stream_namespace, ss.stream_name,
+	       COALESCE(ss.records_emitted, 0) AS records_emitted,
+	       COALESCE(ss.bytes_emitted, 0) AS bytes_emitted
+	FROM latest
+	JOIN stream_stats ss ON ss.attempt_id = latest.attempt_id
+	JOIN connection c ON latest.scope = CAST(c.id AS VARCHAR(255))
+	JOIN actor a2 ON c.source_id = a2.id
+	%[2]s
+	WHERE c.status = 'active'
+	ORDER BY c.id, stream_namespace, ss.stream_name
+	`,
+		ws.columns,
+		ws.join,
+	)
+
+	return r.streamSyncStatsQuery(ctx, query)
+}
+
+// StreamsStatus returns the latest run state of each stream of active connections.
+func (r *Repository) StreamsStatus(ctx context.Context) ([]StreamStatus, error) {
+	ws := r.workspaceGrouping("a2")
+	query := fmt.Sprintf(`
+	SELECT DISTINCT ON (ss.connection_id, ss.stream_namespace, ss.stream_name)
+	       %[1]s ss.connection_id, COALESCE(ss.stream_namespace, '') AS stream_namespace, ss.stream_name, ss.run_state,
+	       COALESCE(CAST(ss.incomplete_run_cause AS VARCHAR), '') AS incomplete_run_cause
+	FROM stream_statuses ss
+	JOIN connection c ON ss.connection_id = c.id
+	JOIN actor a2 ON c.source_id = a2.id
+	%[2]s
+	WHERE c.status = 'active'
+	ORDER BY ss.connection_id, ss.stream_namespace, ss.stream_name, ss.transitioned_at DESC
+	`,
+		ws.columns,
+		ws.join,
+	)
 
-	return r.jobCountQuery(query)
+	return r.streamStatusQuery(ctx, query)
 }
@@ -0,0 +1,140 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package airbyte
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	// cronParser parses cron expressions with a leading seconds field, as used by Quartz.
+	cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+	// ErrUnsupportedCronExpression is returned for Quartz cron expressions using features that have no cron
+	// equivalent: last day (L), nearest weekday (W), nth day of the month (#) and specific years.
+	ErrUnsupportedCronExpression = errors.New("unsupported cron expression")
+)
+
+// ScheduleData holds the schedule of an Airbyte connection, as stored in the connection's schedule_data column.
+type ScheduleData struct {
+	BasicSchedule *BasicSchedule `json:"basicSchedule"`
+	Cron          *CronSchedule  `json:"cron"`
+}
+
+// BasicSchedule holds a schedule that runs syncs at a fixed interval.
+type BasicSchedule struct {
+	TimeUnit string `json:"timeUnit"`
+	Units    int    `json:"units"`
+}
+
+// Next returns the time of the first sync scheduled after t.
+func (bs *BasicSchedule) Next(t time.Time) (time.Time, error) {
+	switch bs.TimeUnit {
+	case "minutes":
+		return t.Add(time.Duration(bs.Units) * time.Minute), nil
+	case "hours":
+		return t.Add(time.Duration(bs.Units) * time.Hour), nil
+	case "days":
+		return t.AddDate(0, 0, bs.Units), nil
+	case "weeks":
+		return t.AddDate(0, 0, 7*bs.Units), nil
+	case "months":
+		return t.AddDate(0, bs.Units, 0), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported basic schedule time unit: %q", bs.TimeUnit)
+}
+
+// CronSchedule holds a schedule that runs syncs according to a Quartz cron expression.
+type CronSchedule struct {
+	CronExpression string `json:"cronExpression"`
+	CronTimeZone   string `json:"cronTimeZone"`
+}
+
+// Next returns the time of the first sync scheduled after t.
+func (cs *CronSchedule) Next(t time.Time) (time.Time, error) {
+	location := time.UTC
+
+	if cs.CronTimeZone != "" {
+		var err error
+
+		location, err = time.LoadLocation(cs.CronTimeZone)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	spec, err := quartzToCronSpec(cs.CronExpression)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	schedule, err := cronParser.Parse(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return schedule.Next(t.In(location)), nil
+}
+
+// quartzToCronSpec converts a Quartz cron expression to a cron specification with a leading seconds field.
+//
+// Quartz expressions have an optional trailing year field, which must be a wildcard, and number days of the
+// week from 1 (Sunday) to 7 (Saturday). The L, W and # special characters are not supported.
+func quartzToCronSpec(expression string) (string, error) {
+	fields := strings.Fields(expression)
+
+	if len(fields) == 7 {
+		if fields[6] != "*" {
+			return "", fmt.Errorf("%w: year field: %q", ErrUnsupportedCronExpression, fields[6])
+		}
+		fields = fields[:6]
+	}
+
+	if len(fields) != 6 {
+		return "", fmt.Errorf("invalid cron expression: %q", expression)
+	}
+
+	// day names may contain W (WED), but not L or #
+	if strings.ContainsAny(fields[3], "LW") || strings.ContainsAny(fields[5], "L#") {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCronExpression, expression)
+	}
+
+	fields[5] = quartzToCronDayOfWeek(fields[5])
+
+	return strings.Join(fields, " "), nil
+}
+
+// quartzToCronDayOfWeek converts numeric days of the week from Quartz (1-7) to cron (0-6) numbering.
+func quartzToCronDayOfWeek(field string) string {
+	items := strings.Split(field, ",")
+
+	for i, item := range items {
+		days, step, hasStep := strings.Cut(item, "/")
+
+		bounds := strings.Split(days, "-")
+		for j, bound := range bounds {
+			day, err := strconv.Atoi(bound)
+			if err != nil {
+				// wildcard or day name
+				continue
+			}
+			bounds[j] = strconv.Itoa(day - 1)
+		}
+
+		items[i] = strings.Join(bounds, "-")
+		if hasStep {
+			items[i] += "/" + step
+		}
+	}
+
+	return strings.Join(items, ",")
+}
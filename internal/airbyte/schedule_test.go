@@ -0,0 +1,197 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package airbyte
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQuartzToCronSpec(t *testing.T) {
+	cases := []struct {
+		tname      string
+		expression string
+		want       string
+	}{
+		{tname: "every day at noon", expression: "0 0 12 * * ?", want: "0 0 12 * * ?"},
+		{tname: "wildcard year", expression: "0 0/15 * * * ? *", want: "0 0/15 * * * ?"},
+		{tname: "weekday range", expression: "0 0 2 ? * 2-6", want: "0 0 2 ? * 1-5"},
+		{tname: "weekday list", expression: "0 0 8 ? * 1,7", want: "0 0 8 ? * 0,6"},
+		{tname: "weekday step", expression: "0 0 8 ? * 2/2", want: "0 0 8 ? * 1/2"},
+		{tname: "weekday names", expression: "0 0 8 ? * MON-WED", want: "0 0 8 ? * MON-WED"},
+		{tname: "extra whitespace", expression: " 0  0 12 * * ? ", want: "0 0 12 * * ?"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := quartzToCronSpec(tc.expression)
+			if err != nil {
+				t.Fatalf("want no error, got %q", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestQuartzToCronSpecUnsupported(t *testing.T) {
+	cases := []struct {
+		tname      string
+		expression string
+	}{
+		{tname: "last day of the month", expression: "0 30 6 L * ?"},
+		{tname: "last weekday of the month", expression: "0 30 6 LW * ?"},
+		{tname: "nearest weekday", expression: "0 0 12 15W * ?"},
+		{tname: "last day of the week", expression: "0 0 12 ? * 6L"},
+		{tname: "nth day of the week", expression: "0 0 12 ? * 6#3"},
+		{tname: "specific year", expression: "0 0 12 * * ? 2025"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			_, err := quartzToCronSpec(tc.expression)
+			if !errors.Is(err, ErrUnsupportedCronExpression) {
+				t.Errorf("want ErrUnsupportedCronExpression, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	cases := []struct {
+		tname    string
+		schedule CronSchedule
+		from     time.Time
+		want     time.Time
+	}{
+		{
+			tname:    "every day at noon, before noon",
+			schedule: CronSchedule{CronExpression: "0 0 12 * * ?"},
+			from:     time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:    "every day at noon, after noon",
+			schedule: CronSchedule{CronExpression: "0 0 12 * * ?"},
+			from:     time.Date(2024, time.January, 15, 13, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 16, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:    "every 15 minutes with a year field",
+			schedule: CronSchedule{CronExpression: "0 0/15 * * * ? *"},
+			from:     time.Date(2024, time.January, 15, 10, 7, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 15, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			tname:    "Monday to Friday, from a Friday",
+			schedule: CronSchedule{CronExpression: "0 0 2 ? * 2-6"},
+			from:     time.Date(2024, time.January, 19, 3, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 22, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:    "Monday to Friday, from a Tuesday",
+			schedule: CronSchedule{CronExpression: "0 0 2 ? * 2-6"},
+			from:     time.Date(2024, time.January, 16, 3, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 17, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:    "Sunday and Saturday, from a Wednesday",
+			schedule: CronSchedule{CronExpression: "0 0 8 ? * 1,7"},
+			from:     time.Date(2024, time.January, 17, 12, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 20, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:    "Sunday and Saturday, from a Saturday",
+			schedule: CronSchedule{CronExpression: "0 0 8 ? * 1,7"},
+			from:     time.Date(2024, time.January, 20, 12, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 21, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:    "every day at noon, in another time zone",
+			schedule: CronSchedule{CronExpression: "0 0 12 * * ?", CronTimeZone: "Europe/Paris"},
+			from:     time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.January, 16, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:    "every day at noon, in another time zone, across a DST change",
+			schedule: CronSchedule{CronExpression: "0 0 12 * * ?", CronTimeZone: "America/New_York"},
+			from:     time.Date(2024, time.March, 9, 18, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, time.March, 10, 16, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := tc.schedule.Next(tc.from)
+			if err != nil {
+				t.Fatalf("want no error, got %q", err)
+			}
+
+			if !got.Equal(tc.want) {
+				t.Errorf("want %s, got %s", tc.want, got.UTC())
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextError(t *testing.T) {
+	cases := []struct {
+		tname    string
+		schedule CronSchedule
+	}{
+		{tname: "unsupported expression", schedule: CronSchedule{CronExpression: "0 30 6 L * ?"}},
+		{tname: "missing fields", schedule: CronSchedule{CronExpression: "0 12 * * ?"}},
+		{tname: "invalid field", schedule: CronSchedule{CronExpression: "0 0 25 * * ?"}},
+		{tname: "unknown time zone", schedule: CronSchedule{CronExpression: "0 0 12 * * ?", CronTimeZone: "Mars/Olympus_Mons"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			if _, err := tc.schedule.Next(time.Now()); err == nil {
+				t.Error("want an error, got none")
+			}
+		})
+	}
+}
+
+func TestBasicScheduleNext(t *testing.T) {
+	from := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		tname    string
+		schedule BasicSchedule
+		want     time.Time
+	}{
+		{tname: "minutes", schedule: BasicSchedule{TimeUnit: "minutes", Units: 15}, want: time.Date(2024, time.January, 15, 10, 15, 0, 0, time.UTC)},
+		{tname: "hours", schedule: BasicSchedule{TimeUnit: "hours", Units: 24}, want: time.Date(2024, time.January, 16, 10, 0, 0, 0, time.UTC)},
+		{tname: "days", schedule: BasicSchedule{TimeUnit: "days", Units: 2}, want: time.Date(2024, time.January, 17, 10, 0, 0, 0, time.UTC)},
+		{tname: "weeks", schedule: BasicSchedule{TimeUnit: "weeks", Units: 1}, want: time.Date(2024, time.January, 22, 10, 0, 0, 0, time.UTC)},
+		{tname: "months", schedule: BasicSchedule{TimeUnit: "months", Units: 1}, want: time.Date(2024, time.February, 15, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := tc.schedule.Next(from)
+			if err != nil {
+				t.Fatalf("want no error, got %q", err)
+			}
+
+			if !got.Equal(tc.want) {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("unsupported time unit", func(t *testing.T) {
+		schedule := BasicSchedule{TimeUnit: "fortnights", Units: 1}
+
+		if _, err := schedule.Next(from); err == nil {
+			t.Error("want an error, got none")
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package airbyte
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semanticVersion holds the components of a semantic version that determine its precedence.
+type semanticVersion struct {
+	core       [3]int
+	prerelease []string
+}
+
+// compareVersions compares two semantic versions, ignoring build metadata.
+//
+// A pre-release version precedes the associated normal version, e.g. 1.0.0-rc1 precedes 1.0.0.
+//
+// It returns -1 if a precedes b, 1 if a follows b, and 0 if both versions are equal.
+func compareVersions(a, b string) (int, error) {
+	aVersion, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+
+	bVersion, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range aVersion.core {
+		switch {
+		case aVersion.core[i] < bVersion.core[i]:
+			return -1, nil
+		case aVersion.core[i] > bVersion.core[i]:
+			return 1, nil
+		}
+	}
+
+	return comparePrereleases(aVersion.prerelease, bVersion.prerelease), nil
+}
+
+// comparePrereleases compares the pre-release identifiers of two versions sharing the same major, minor
+// and patch numbers, following the precedence rules of Semantic Versioning 2.0.0.
+func comparePrereleases(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if cmp := comparePrereleaseIdentifiers(a[i], b[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+
+	return 0
+}
+
+// comparePrereleaseIdentifiers compares two pre-release identifiers: numeric identifiers are compared
+// numerically and precede alphanumeric identifiers, which are compared lexically.
+func comparePrereleaseIdentifiers(a, b string) int {
+	aNumber, aErr := strconv.Atoi(a)
+	bNumber, bErr := strconv.Atoi(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case aNumber < bNumber:
+			return -1
+		case aNumber > bNumber:
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// parseVersion returns the major, minor and patch numbers and the pre-release identifiers of a semantic version.
+func parseVersion(version string) (semanticVersion, error) {
+	var parsed semanticVersion
+
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	core, prerelease, hasPrerelease := strings.Cut(core, "-")
+
+	fields := strings.Split(core, ".")
+	if len(fields) != 3 {
+		return parsed, fmt.Errorf("invalid semantic version: %q", version)
+	}
+
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return parsed, fmt.Errorf("invalid semantic version: %q", version)
+		}
+		parsed.core[i] = number
+	}
+
+	if hasPrerelease {
+		if prerelease == "" {
+			return parsed, fmt.Errorf("invalid semantic version: %q", version)
+		}
+		parsed.prerelease = strings.Split(prerelease, ".")
+	}
+
+	return parsed, nil
+}
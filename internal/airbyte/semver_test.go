@@ -0,0 +1,95 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package airbyte
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		tname string
+		a     string
+		b     string
+		want  int
+	}{
+		{tname: "equal", a: "1.2.3", b: "1.2.3", want: 0},
+		{tname: "v prefix", a: "v1.2.3", b: "1.2.3", want: 0},
+		{tname: "build metadata ignored", a: "1.2.3+build.5", b: "1.2.3", want: 0},
+		{tname: "major precedes", a: "1.9.9", b: "2.0.0", want: -1},
+		{tname: "minor follows", a: "1.10.0", b: "1.9.0", want: 1},
+		{tname: "patch precedes", a: "1.2.3", b: "1.2.4", want: -1},
+		{tname: "pre-release precedes release", a: "1.0.0-rc1", b: "1.0.0", want: -1},
+		{tname: "release follows pre-release", a: "1.0.0", b: "1.0.0-rc1", want: 1},
+		{tname: "pre-release of next version follows", a: "1.0.1-rc1", b: "1.0.0", want: 1},
+		{tname: "numeric pre-release identifiers", a: "1.0.0-rc.2", b: "1.0.0-rc.10", want: -1},
+		{tname: "numeric precedes alphanumeric", a: "1.0.0-1", b: "1.0.0-alpha", want: -1},
+		{tname: "alphanumeric pre-release identifiers", a: "1.0.0-beta", b: "1.0.0-alpha", want: 1},
+		{tname: "shorter pre-release precedes", a: "1.0.0-alpha", b: "1.0.0-alpha.1", want: -1},
+		{tname: "equal pre-releases", a: "1.0.0-alpha.1", b: "1.0.0-alpha.1", want: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := compareVersions(tc.a, tc.b)
+			if err != nil {
+				t.Fatalf("want no error, got %q", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("compareVersions(%q, %q): want %d, got %d", tc.a, tc.b, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCompareVersionsInvalid(t *testing.T) {
+	cases := []string{
+		"dev",
+		"latest",
+		"1.2",
+		"1.2.3.4",
+		"1.2.x",
+		"1.2.3-",
+	}
+
+	for _, version := range cases {
+		t.Run(version, func(t *testing.T) {
+			if _, err := compareVersions(version, "1.0.0"); err == nil {
+				t.Errorf("compareVersions(%q, %q): want an error, got none", version, "1.0.0")
+			}
+		})
+	}
+}
+
+func TestActorBreakingChangeAffected(t *testing.T) {
+	cases := []struct {
+		tname          string
+		dockerImageTag string
+		version        string
+		want           bool
+	}{
+		{tname: "older version", dockerImageTag: "0.9.1", version: "1.0.0", want: true},
+		{tname: "pre-release of breaking version", dockerImageTag: "1.0.0-rc1", version: "1.0.0", want: true},
+		{tname: "breaking version", dockerImageTag: "1.0.0", version: "1.0.0", want: false},
+		{tname: "newer version", dockerImageTag: "1.2.0", version: "1.0.0", want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tname, func(t *testing.T) {
+			abc := &ActorBreakingChange{
+				DockerImageTag: tc.dockerImageTag,
+				Version:        tc.version,
+			}
+
+			got, err := abc.Affected()
+			if err != nil {
+				t.Fatalf("want no error, got %q", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -4,62 +4,190 @@
 
 package airbyte
 
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ServiceOptions holds settings to gather optional metrics.
+type ServiceOptions struct {
+	// StreamMetrics enables per-stream metrics, which can have a high cardinality.
+	StreamMetrics bool
+
+	// UnusedActorMetrics enables per-actor metrics for sources and destinations that are not used
+	// by any active connection.
+	UnusedActorMetrics bool
+
+	// MaxConcurrentQueries limits the number of database queries run concurrently; there is no limit if it
+	// is lower than or equal to 0.
+	MaxConcurrentQueries int
+
+	// QueryTimeout cancels database queries that do not complete in time; there is no timeout if it is
+	// lower than or equal to 0.
+	QueryTimeout time.Duration
+}
+
 // Service handles domain operations for gathering metrics from Airbyte's PostgreSQL database.
 type Service struct {
 	r *Repository
+
+	opts ServiceOptions
+
+	snapshotMu sync.RWMutex
+	snapshot   *Snapshot
 }
 
 // NewService initializes and returns an Airbyte Service.
-func NewService(r *Repository) *Service {
+func NewService(r *Repository, opts ServiceOptions) *Service {
 	return &Service{
-		r: r,
+		r:    r,
+		opts: opts,
 	}
 }
 
-// GatherMetrics gathers and returns metrics from Airbyte's PostgreSQL database.
-func (s *Service) GatherMetrics() (*Metrics, error) {
-	connections, err := s.r.ConnectionsCount()
-	if err != nil {
-		return &Metrics{}, err
+// query holds a named database query gathering a group of Airbyte metrics.
+type query struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// into returns a function that runs fetch and stores its results into dst.
+func into[T any](dst *[]T, fetch func(ctx context.Context) ([]T, error)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		rows, err := fetch(ctx)
+		if err != nil {
+			return err
+		}
+
+		*dst = rows
+
+		return nil
+	}
+}
+
+// queries returns the database queries gathering enabled Airbyte metrics into metrics.
+//
+// Each query stores its results into a distinct field of metrics, so that queries can run concurrently.
+func (s *Service) queries(metrics *Metrics) []query {
+	queries := []query{
+		{name: "build_info", run: into(&metrics.BuildInfo, s.r.BuildInfo)},
+		{name: "connections", run: into(&metrics.Connections, s.r.ConnectionsCount)},
+		{name: "connections_broken_references", run: into(&metrics.ConnectionsBrokenReferences, s.r.ConnectionsBrokenReferenceCount)},
+		{name: "connections_info", run: into(&metrics.ConnectionsInfo, s.r.ConnectionsInfo)},
+		{name: "connections_last_successful_sync_ages", run: into(&metrics.ConnectionsLastSuccessfulSyncAges, s.r.ConnectionsLastSuccessfulSyncAge)},
+		{name: "connections_sync_schedules", run: into(&metrics.ConnectionsSyncSchedules, s.r.ConnectionsSyncSchedule)},
+		{name: "connections_last_syncs", run: into(&metrics.ConnectionsLastSyncs, s.r.ConnectionsLastSync)},
+		{name: "connections_consecutive_failures", run: into(&metrics.ConnectionsConsecutiveFailures, s.r.ConnectionsConsecutiveFailuresCount)},
+		{name: "connections_never_synced", run: into(&metrics.ConnectionsNeverSynced, s.r.ConnectionsNeverSyncedCount)},
+		{name: "connections_never_synced_ages", run: into(&metrics.ConnectionsNeverSyncedAges, s.r.ConnectionsNeverSyncedAge)},
+		{name: "connections_schema_changes_pending", run: into(&metrics.ConnectionsSchemaChangesPending, s.r.ConnectionsSchemaChangePendingCount)},
+		{name: "connections_schema_change_ages", run: into(&metrics.ConnectionsSchemaChangeAges, s.r.ConnectionsSchemaChangePendingAge)},
+		{name: "sources", run: into(&metrics.Sources, s.r.SourcesCount)},
+		{name: "destinations", run: into(&metrics.Destinations, s.r.DestinationsCount)},
+		{name: "sources_unused", run: into(&metrics.SourcesUnused, s.r.SourcesUnusedCount)},
+		{name: "destinations_unused", run: into(&metrics.DestinationsUnused, s.r.DestinationsUnusedCount)},
+		{name: "connector_versions", run: into(&metrics.ConnectorVersions, s.r.ConnectorVersionsCount)},
+		{name: "actors_breaking_changes", run: into(&metrics.ActorsBreakingChanges, s.r.ActorsBreakingChanges)},
+		{name: "jobs_completed", run: into(&metrics.JobsCompleted, s.r.JobsCompletedCount)},
+		{name: "jobs_pending", run: into(&metrics.JobsPending, s.r.JobsPendingCount)},
+		{name: "jobs_running", run: into(&metrics.JobsRunning, s.r.JobsRunningCount)},
+		{name: "jobs_pending_ages", run: into(&metrics.JobsPendingAges, s.r.JobsPendingAge)},
+		{name: "jobs_queue_waits", run: into(&metrics.JobsQueueWaits, s.r.JobsCompletedQueueWait)},
+		{name: "jobs_running_ages", run: into(&metrics.JobsRunningAges, s.r.JobsRunningAge)},
+		{name: "attempts_running_ages", run: into(&metrics.AttemptsRunningAges, s.r.AttemptsRunningAge)},
+		{name: "job_durations", run: into(&metrics.JobDurations, s.r.JobsCompletedDuration)},
+		{name: "attempt_durations", run: into(&metrics.AttemptDurations, s.r.AttemptsCompletedDuration)},
+		{name: "job_attempts", run: into(&metrics.JobAttempts, s.r.JobsCompletedAttempts)},
+		{name: "attempts_failed", run: into(&metrics.AttemptsFailed, s.r.AttemptsFailedCount)},
+		{name: "attempt_failures", run: into(&metrics.AttemptFailures, s.r.AttemptFailuresCount)},
+		{name: "reset_jobs_completed", run: into(&metrics.ResetJobsCompleted, s.r.ResetJobsCompletedCount)},
+		{name: "streams_pending_reset", run: into(&metrics.StreamsPendingReset, s.r.StreamsPendingResetCount)},
+		{name: "streams_pending_refresh", run: into(&metrics.StreamsPendingRefresh, s.r.StreamsPendingRefreshCount)},
+		{name: "sync_volumes", run: into(&metrics.SyncVolumes, s.r.SyncVolumes)},
+	}
+
+	if s.opts.UnusedActorMetrics {
+		queries = append(
+			queries,
+			query{name: "unused_actors", run: into(&metrics.UnusedActors, s.r.UnusedActorsAge)},
+		)
 	}
 
-	connectionsLastSuccessfulSyncAges, err := s.r.ConnectionsLastSuccessfulSyncAge()
-	if err != nil {
-		return &Metrics{}, err
+	if s.opts.StreamMetrics {
+		queries = append(
+			queries,
+			query{name: "streams_last_sync_stats", run: into(&metrics.StreamsLastSyncStats, s.r.StreamsLastSyncStats)},
+			query{name: "streams_status", run: into(&metrics.StreamsStatus, s.r.StreamsStatus)},
+		)
 	}
 
-	sources, err := s.r.SourcesCount()
-	if err != nil {
-		return &Metrics{}, err
+	return queries
+}
+
+// queryContext returns a copy of ctx that is canceled when the query timeout elapses, if any.
+func (s *Service) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.opts.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
 	}
 
-	destinations, err := s.r.DestinationsCount()
-	if err != nil {
-		return &Metrics{}, err
+	return context.WithTimeout(ctx, s.opts.QueryTimeout)
+}
+
+// GatherMetrics gathers and returns metrics from Airbyte's PostgreSQL database.
+//
+// Database queries run concurrently, up to the configured limit, and are canceled when ctx is done.
+//
+// If some queries fail, the metrics gathered by other queries are returned along with a *GatherError
+// listing the failed queries. The outcome of each query is always returned along with metrics.
+func (s *Service) GatherMetrics(ctx context.Context) (*Metrics, error) {
+	metrics := &Metrics{}
+	queries := s.queries(metrics)
+	results := make([]QueryResult, len(queries))
+
+	g := new(errgroup.Group)
+	if s.opts.MaxConcurrentQueries > 0 {
+		g.SetLimit(s.opts.MaxConcurrentQueries)
 	}
 
-	jobsCompleted, err := s.r.JobsCompletedCount()
-	if err != nil {
-		return &Metrics{}, err
+	for i, q := range queries {
+		i, q := i, q
+
+		g.Go(func() error {
+			queryCtx, cancel := s.queryContext(ctx)
+			defer cancel()
+
+			start := time.Now()
+			err := q.run(queryCtx)
+
+			results[i] = QueryResult{
+				Name:     q.name,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+
+			return nil
+		})
 	}
 
-	jobsPending, err := s.r.JobsPendingCount()
-	if err != nil {
-		return &Metrics{}, err
+	// queries report their errors in their results
+	_ = g.Wait()
+
+	metrics.Queries = results
+
+	var gatherErr GatherError
+
+	for _, result := range results {
+		if result.Err != nil {
+			gatherErr.Errors = append(gatherErr.Errors, &QueryError{Query: result.Name, Err: result.Err})
+		}
 	}
 
-	jobsRunning, err := s.r.JobsRunningCount()
-	if err != nil {
-		return &Metrics{}, err
+	if len(gatherErr.Errors) > 0 {
+		return metrics, &gatherErr
 	}
 
-	return &Metrics{
-		Connections:                       connections,
-		ConnectionsLastSuccessfulSyncAges: connectionsLastSuccessfulSyncAges,
-		Sources:                           sources,
-		Destinations:                      destinations,
-		JobsCompleted:                     jobsCompleted,
-		JobsPending:                       jobsPending,
-		JobsRunning:                       jobsRunning,
-	}, nil
+	return metrics, nil
 }
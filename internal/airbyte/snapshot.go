@@ -0,0 +1,56 @@
+// Copyright 2023 VirtualTam.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package airbyte
+
+import (
+	"context"
+	"time"
+)
+
+// Snapshot holds Airbyte metrics gathered at a given time.
+type Snapshot struct {
+	Metrics   *Metrics
+	Timestamp time.Time
+}
+
+// Refresh gathers Airbyte metrics and stores them as the latest snapshot, and returns the error that occurred
+// while gathering them, if any.
+//
+// If every query failed, the metrics and timestamp of the previous snapshot are kept, along with the outcome
+// of the failed queries.
+func (s *Service) Refresh(ctx context.Context) error {
+	metrics, err := s.GatherMetrics(ctx)
+	timestamp := time.Now()
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	if !metrics.Gathered() {
+		if s.snapshot == nil {
+			return err
+		}
+
+		previousMetrics := *s.snapshot.Metrics
+		previousMetrics.Queries = metrics.Queries
+
+		metrics = &previousMetrics
+		timestamp = s.snapshot.Timestamp
+	}
+
+	s.snapshot = &Snapshot{
+		Metrics:   metrics,
+		Timestamp: timestamp,
+	}
+
+	return err
+}
+
+// LatestSnapshot returns the latest metrics snapshot, or nil if metrics have not been refreshed yet.
+func (s *Service) LatestSnapshot() *Snapshot {
+	s.snapshotMu.RLock()
+	defer s.snapshotMu.RUnlock()
+
+	return s.snapshot
+}